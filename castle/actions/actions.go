@@ -0,0 +1,127 @@
+package actions
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//Action invokes a module, performed by rules and scheduled jobs
+type Action struct {
+	//Type is one of gpio, radio, snapshot or webhook
+	Type     string        `json:"type" enum:"gpio,radio,snapshot,webhook"`
+	Pin      int           `json:"pin,omitempty" help:"gpio pin to actuate"`
+	Duration util.Duration `json:"duration,omitempty" help:"time to actuate the gpio pin"`
+	Code     uint32        `json:"code,omitempty" help:"radio code to send"`
+	URL      string        `json:"url,omitempty" help:"webhook to post to"`
+}
+
+//Validate checks the action has what its type requires
+func (a *Action) Validate() error {
+	switch a.Type {
+	case "gpio":
+		if a.Pin <= 0 {
+			return errors.New("missing pin")
+		}
+		if a.Duration <= 0 {
+			return errors.New("missing duration")
+		}
+	case "radio":
+		if a.Code == 0 {
+			return errors.New("missing code")
+		}
+	case "snapshot":
+	case "webhook":
+		if u, err := url.Parse(a.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return errors.New("invalid url")
+		}
+	default:
+		return fmt.Errorf("unknown type: %s", a.Type)
+	}
+	return nil
+}
+
+//Pulser drives gpio pins
+type Pulser interface {
+	Pulse(pin int, d time.Duration) error
+}
+
+//Sender transmits radio codes
+type Sender interface {
+	Send(code uint32) error
+}
+
+//Snapper stores webcam snaps
+type Snapper interface {
+	Snapshot() (string, error)
+}
+
+//Runner performs actions using the given modules
+type Runner struct {
+	GPIO   Pulser
+	Radio  Sender
+	Webcam Snapper
+	//Timeout limits webhooks
+	Timeout time.Duration
+}
+
+//Run performs a, posting payload to webhooks,
+//and describes the result
+func (r *Runner) Run(a *Action, payload interface{}) (string, error) {
+	switch a.Type {
+	case "gpio":
+		if err := r.GPIO.Pulse(a.Pin, a.Duration.D()); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("pin %d for %s", a.Pin, a.Duration.D()), nil
+	case "radio":
+		if err := r.Radio.Send(a.Code); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("sent %d", a.Code), nil
+	case "snapshot":
+		return r.Webcam.Snapshot()
+	case "webhook":
+		return r.webhook(a.URL, payload)
+	}
+	return "", fmt.Errorf("unknown type: %s", a.Type)
+}
+
+func (r *Runner) webhook(url string, payload interface{}) (string, error) {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	client := http.Client{Timeout: r.Timeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return "", errors.New(resp.Status)
+	}
+	return resp.Status, nil
+}
+
+//RunAll performs each action in turn, returning their
+//results and whether any failed
+func (r *Runner) RunAll(list []Action, payload interface{}) ([]string, bool) {
+	results := []string{}
+	failed := false
+	for _, a := range list {
+		result, err := r.Run(&a, payload)
+		if err != nil {
+			failed = true
+			result = "error: " + err.Error()
+		}
+		results = append(results, a.Type+": "+result)
+	}
+	return results, failed
+}
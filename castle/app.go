@@ -1,49 +1,71 @@
 package castle
 
 import (
+	"context"
 	"errors"
-	"log"
 	"net/http"
 	"net/http/pprof"
+	"os"
+	"os/signal"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"goji.io"
 	"goji.io/pat"
 
 	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/actions"
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/metrics"
 	"github.com/jpillora/castlebot/castle/modules"
 	"github.com/jpillora/castlebot/castle/modules/auth"
+	"github.com/jpillora/castlebot/castle/modules/backup"
+	"github.com/jpillora/castlebot/castle/modules/eventlog"
 	"github.com/jpillora/castlebot/castle/modules/gpio"
 	"github.com/jpillora/castlebot/castle/modules/machine"
 	"github.com/jpillora/castlebot/castle/modules/radio"
+	"github.com/jpillora/castlebot/castle/modules/rules"
 	"github.com/jpillora/castlebot/castle/modules/scanner"
+	"github.com/jpillora/castlebot/castle/modules/scheduler"
 	"github.com/jpillora/castlebot/castle/modules/server"
 	"github.com/jpillora/castlebot/castle/modules/webcam"
 	"github.com/jpillora/castlebot/castle/static"
 	"github.com/jpillora/overseer"
-	"github.com/jpillora/requestlog"
 	"github.com/jpillora/velox"
 )
 
 //Config defines the command-line interface, it contains just enough to access
 //the web ui to change the settings database
 type Config struct {
-	DB      string `help:"castle settings database location"`
-	Name    string `help:"name of bot"`
-	Port    int    `help:"http listening port, used when not found in settings"`
-	Updates bool   `help:"enable automatic updates"`
+	DB        string `help:"castle settings database location"`
+	Name      string `help:"name of bot"`
+	Port      int    `help:"http listening port, used when not found in settings"`
+	Updates   bool   `help:"enable automatic updates"`
+	LogFormat string `help:"log output format, text or json"`
 }
 
+//stopTimeout bounds how long in-flight requests
+//and modules may each take to stop
+const stopTimeout = 10 * time.Second
+
 func Run(buildtime string, config Config, state overseer.State) error {
 	//validate config
 	if config.DB == "" {
 		return errors.New("database location is required")
 	}
+	format, err := logs.ParseFormat(config.LogFormat)
+	if err != nil {
+		return err
+	}
+	logs.SetFormat(format)
+	logger := logs.New("castle")
 	//setup database
-	log.Printf("Open database %s", config.DB)
+	logger.Info("Open database", "path", config.DB)
 	db, err := bolt.Open(config.DB, 0600, nil)
 	if err != nil {
 		return err
@@ -65,30 +87,43 @@ func Run(buildtime string, config Config, state overseer.State) error {
 	}
 	if n, err := strconv.ParseInt(buildtime, 10, 64); err == nil {
 		data.BuildTime = time.Unix(n, 0)
-		log.Printf("build time: %s", data.BuildTime)
+		logger.Info("build time", "time", data.BuildTime)
 	}
 	//root router
 	router := goji.NewMux()
-	//initialise module container
-	m := modules.New(db, router, velox.Pusher(&data))
+	//initialise event bus and module container
+	bus := events.NewBus()
+	reg := metrics.NewRegistry()
+	m := modules.New(db, router, velox.Pusher(&data), bus, reg)
 	data.Modules = m.JSON()
 	//initialise modules
-	a := auth.New()
+	a := auth.New(db)
 	serv := server.New(db, router, config.Port)
+	bk := backup.New(db)
+	el := eventlog.New(db)
+	g := gpio.New()
+	wc := webcam.New(db)
+	rd := radio.New()
+	runner := actions.Runner{GPIO: g, Radio: rd, Webcam: wc}
 	mods := []modules.Identified{
 		serv,
 		a,
-		gpio.New(),
+		g,
 		scanner.New(),
-		webcam.New(db),
+		wc,
 		machine.New(),
-		radio.New(),
+		rd,
+		bk,
+		el,
+		rules.New(db, runner),
+		scheduler.New(runner),
 	}
 	//HACK: let goroutines kick in
 	time.Sleep(50 * time.Millisecond)
 	//setup middleware
-	router.Use(requestlog.Wrap)
-	router.Use(a.CookieAuth.Wrap)
+	router.Use(logs.New("http").Wrap)
+	router.Use(reg.Wrap)
+	router.Use(a.Wrap)
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			//tls hostname check
@@ -103,7 +138,31 @@ func Run(buildtime string, config Config, state overseer.State) error {
 	for _, mod := range mods {
 		m.Register(mod)
 	}
+	//start all modules, stopping them again once the server exits
+	if err := m.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		if err := m.Stop(stopTimeout); err != nil {
+			logger.Error("failed to stop modules", "err", err)
+		}
+	}()
 	//setup admin routes
+	router.Handle(pat.Get("/admin/settings/export"), m.ExportHandler(server.ACMEBucket()))
+	router.Handle(pat.Post("/admin/settings/import"), m.ImportHandler(server.ACMEBucket()))
+	router.Handle(pat.Get("/admin/backup"), http.HandlerFunc(bk.Download))
+	router.Handle(pat.Get("/events"), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		//stream live events to event sources, otherwise query the log
+		if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			bus.ServeHTTP(w, r)
+		} else {
+			el.Query(w, r)
+		}
+	}))
+	router.Handle(pat.Get("/metrics"), reg)
+	router.Handle(pat.Get("/healthz"), m.HealthHandler())
+	router.Handle(pat.Get("/readyz"), m.ReadyHandler())
+	router.Handle(pat.Get("/health"), m.ReportHandler())
 	router.Handle(pat.Get("/admin/pprof"), http.HandlerFunc(pprof.Index))
 	router.Handle(pat.Get("/admin/pprof/cmdline"), http.HandlerFunc(pprof.Cmdline))
 	router.Handle(pat.Get("/admin/pprof/profile"), http.HandlerFunc(pprof.Profile))
@@ -116,6 +175,31 @@ func Run(buildtime string, config Config, state overseer.State) error {
 	router.Handle(pat.Get("/sync"), velox.SyncHandler(&data))
 	router.Handle(pat.Get("/js/velox.js"), velox.JS)
 	router.Handle(pat.New("/*"), static.Handler())
-	//wait till closed
-	return serv.Wait()
+	//wait till closed, interrupted or upgraded
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	closed := make(chan error, 1)
+	go func() {
+		closed <- serv.Wait()
+	}()
+	select {
+	case err := <-closed:
+		return err
+	case sig := <-signals:
+		logger.Info("Received signal, shutting down", "signal", sig)
+	case <-state.GracefulShutdown:
+		logger.Info("Restarting, shutting down")
+	}
+	//stop accepting connections and let in-flight requests
+	//finish, modules and then the database are closed on return.
+	//event streams never finish, so they are closed first.
+	bus.Close()
+	m.CloseLogs()
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	if err := serv.Shutdown(ctx); err != nil {
+		logger.Error("server shutdown failed", "err", err)
+	}
+	return nil
 }
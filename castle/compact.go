@@ -0,0 +1,97 @@
+package castle
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+const compactUsage = `
+  Usage: castle compact [--db <path>]
+
+  Rewrites the database without its free pages while castle
+  is stopped. The original database is kept as <path>.bak
+
+`
+
+//CompactCommand runs the "castle compact" subcommand
+func CompactCommand(db string, args []string) error {
+	flags := flag.NewFlagSet("compact", flag.ContinueOnError)
+	flags.StringVar(&db, "db", db, "castle settings database location")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, compactUsage)
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	before, err := os.Stat(db)
+	if err != nil {
+		return err
+	}
+	src, err := bolt.Open(db, 0600, &bolt.Options{Timeout: time.Second, ReadOnly: true})
+	if err == bolt.ErrTimeout {
+		return errors.New("database is locked, castle must be stopped first")
+	} else if err != nil {
+		return err
+	}
+	defer src.Close()
+	tmp := db + ".compact"
+	os.Remove(tmp)
+	dst, err := bolt.Open(tmp, 0600, nil)
+	if err != nil {
+		return err
+	}
+	if err := src.View(func(stx *bolt.Tx) error {
+		return dst.Update(func(dtx *bolt.Tx) error {
+			return stx.ForEach(func(name []byte, b *bolt.Bucket) error {
+				nb, err := dtx.CreateBucket(name)
+				if err != nil {
+					return err
+				}
+				return copyBucket(nb, b)
+			})
+		})
+	}); err != nil {
+		dst.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	src.Close()
+	if err := os.Rename(db, db+".bak"); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, db); err != nil {
+		return err
+	}
+	after, err := os.Stat(db)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("compacted %s: %d -> %d bytes\n", db, before.Size(), after.Size())
+	return nil
+}
+
+//copyBucket copies all keys, nested buckets
+//and sequences from src into dst
+func copyBucket(dst, src *bolt.Bucket) error {
+	if err := dst.SetSequence(src.Sequence()); err != nil {
+		return err
+	}
+	return src.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return dst.Put(k, v)
+		}
+		nb, err := dst.CreateBucket(k)
+		if err != nil {
+			return err
+		}
+		return copyBucket(nb, src.Bucket(k))
+	})
+}
@@ -0,0 +1,162 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jpillora/castlebot/castle/logs"
+)
+
+//subscriptionBuffer is the number of events held for
+//each subscriber, further events are dropped
+const subscriptionBuffer = 64
+
+//Bus delivers published events to all matching subscribers
+type Bus struct {
+	mut    sync.RWMutex
+	closed bool
+	subs   map[*Subscription]bool
+	log    *logs.Logger
+}
+
+func NewBus() *Bus {
+	return &Bus{subs: map[*Subscription]bool{}, log: logs.New("events")}
+}
+
+//Subscription receives events on C until closed
+type Subscription struct {
+	C <-chan Event
+	c chan Event
+	//dropped counts the events missed while
+	//falling behind, accessed atomically
+	dropped uint64
+	bus     *Bus
+	types   map[Type]bool
+	module  string
+}
+
+//Publish delivers e to all subscribers without blocking.
+//Subscribers which have fallen behind miss the event.
+func (b *Bus) Publish(e Event) {
+	if b == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	b.mut.RLock()
+	defer b.mut.RUnlock()
+	for s := range b.subs {
+		if !s.matches(e) {
+			continue
+		}
+		select {
+		case s.c <- e:
+		default:
+			atomic.AddUint64(&s.dropped, 1)
+		}
+	}
+}
+
+//Subscribe to events of module (all modules when empty)
+//and of the given types (all types when none)
+func (b *Bus) Subscribe(module string, types ...Type) *Subscription {
+	c := make(chan Event, subscriptionBuffer)
+	s := &Subscription{C: c, c: c, bus: b, module: module}
+	if len(types) > 0 {
+		s.types = map[Type]bool{}
+		for _, t := range types {
+			s.types[t] = true
+		}
+	}
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	if b.closed {
+		close(c)
+		return s
+	}
+	b.subs[s] = true
+	return s
+}
+
+func (s *Subscription) matches(e Event) bool {
+	if s.module != "" && s.module != e.Module {
+		return false
+	}
+	return s.types == nil || s.types[e.Type]
+}
+
+//Dropped is the number of events missed
+//while the subscriber was falling behind
+func (s *Subscription) Dropped() uint64 {
+	return atomic.LoadUint64(&s.dropped)
+}
+
+//Close unsubscribes, closing C
+func (s *Subscription) Close() {
+	b := s.bus
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	if b.subs[s] {
+		delete(b.subs, s)
+		close(s.c)
+	}
+}
+
+//Close closes all subscriptions, allowing
+//streaming clients to disconnect
+func (b *Bus) Close() {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	b.closed = true
+	for s := range b.subs {
+		delete(b.subs, s)
+		close(s.c)
+	}
+}
+
+//ServeHTTP streams events as server-sent events, optionally
+//filtered with ?module=<id> and any number of ?type=<type>
+func (b *Bus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	q := r.URL.Query()
+	types := []Type{}
+	for _, t := range q["type"] {
+		types = append(types, Type(t))
+	}
+	sub := b.Subscribe(q.Get("module"), types...)
+	defer sub.Close()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	ping := time.NewTicker(30 * time.Second)
+	defer ping.Stop()
+	for {
+		select {
+		case e, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				b.log.Error("invalid event", "type", e.Type, "err", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+		case <-ping.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,114 @@
+package events
+
+import (
+	"time"
+
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//Type identifies the kind of an event
+type Type string
+
+const (
+	//HostArrived is published by the scanner when a
+	//host is seen for the first time, or after leaving
+	HostArrived Type = "host.arrived"
+	//HostLeft is published by the scanner when a host has not
+	//been seen for longer than the active threshold
+	HostLeft Type = "host.left"
+	//Motion is published by the webcam when consecutive
+	//snaps differ by more than the threshold
+	Motion Type = "webcam.motion"
+	//PinActuated is published by gpio when a pin is driven high
+	PinActuated Type = "gpio.actuated"
+	//PinEdge is published by gpio when a watched input pin changes
+	PinEdge Type = "gpio.edge"
+	//RadioSent is published by radio when a code is sent
+	RadioSent Type = "radio.sent"
+	//MachineStats is published by machine each time it samples
+	MachineStats Type = "machine.stats"
+	//JobRun is published by the scheduler when a job runs
+	JobRun Type = "scheduler.run"
+	//SettingsChanged is published on behalf of
+	//a module when its settings are updated
+	SettingsChanged Type = "settings.changed"
+	//Login is published by auth when credentials are presented
+	Login Type = "auth.login"
+	//Lockout is published by auth when an address or
+	//user is locked out after repeated failed logins
+	Lockout Type = "auth.lockout"
+)
+
+//Event is a single occurrence within a module
+type Event struct {
+	Module string      `json:"module"`
+	Type   Type        `json:"type"`
+	Time   time.Time   `json:"time"`
+	Data   interface{} `json:"data,omitempty"`
+}
+
+//Host is the data of HostArrived and HostLeft events
+type Host struct {
+	IP       string `json:"ip"`
+	MAC      string `json:"mac,omitempty"`
+	Hostname string `json:"hostname,omitempty"`
+}
+
+//MotionData is the data of Motion events
+type MotionData struct {
+	Snap string `json:"snap"`
+	Diff int    `json:"diff"`
+}
+
+//Pin is the data of PinActuated events
+type Pin struct {
+	Pin      int           `json:"pin"`
+	Duration util.Duration `json:"duration"`
+}
+
+//Edge is the data of PinEdge events
+type Edge struct {
+	Pin  int  `json:"pin"`
+	High bool `json:"high"`
+}
+
+//Radio is the data of RadioSent events
+type Radio struct {
+	Code uint32 `json:"code"`
+}
+
+//Job is the data of JobRun events
+type Job struct {
+	Name    string   `json:"name"`
+	Results []string `json:"results"`
+}
+
+//Change is the data of SettingsChanged events
+type Change struct {
+	User string `json:"user,omitempty"`
+}
+
+//LoginData is the data of Login events
+type LoginData struct {
+	User    string `json:"user"`
+	IP      string `json:"ip"`
+	Success bool   `json:"success"`
+}
+
+//LockoutData is the data of Lockout events,
+//either IP or User is locked out
+type LockoutData struct {
+	IP       string    `json:"ip,omitempty"`
+	User     string    `json:"user,omitempty"`
+	Failures int       `json:"failures"`
+	Until    time.Time `json:"until"`
+}
+
+//Stats is the data of MachineStats events
+type Stats struct {
+	CPU         float64 `json:"cpu"`
+	DiskUsed    int64   `json:"diskUsed"`
+	DiskTotal   int64   `json:"diskTotal"`
+	MemoryUsed  int64   `json:"memoryUsed"`
+	MemoryTotal int64   `json:"memoryTotal"`
+}
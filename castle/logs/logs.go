@@ -0,0 +1,292 @@
+package logs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Level is the severity of a log entry
+type Level int
+
+const (
+	Debug Level = iota
+	Info
+	Warn
+	Error
+)
+
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+func (l Level) String() string {
+	if l < Debug || l > Error {
+		return "unknown"
+	}
+	return levelNames[l]
+}
+
+//ParseLevel parses the name of a level
+func ParseLevel(s string) (Level, error) {
+	for i, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return Level(i), nil
+		}
+	}
+	return Info, fmt.Errorf("invalid level: %s (expected one of %s)", s, strings.Join(levelNames, ", "))
+}
+
+func (l Level) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.String())
+}
+
+func (l *Level) UnmarshalJSON(b []byte) error {
+	s := ""
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := ParseLevel(s)
+	if err != nil {
+		return err
+	}
+	*l = v
+	return nil
+}
+
+//Entry is a single log message
+type Entry struct {
+	Time    time.Time              `json:"time"`
+	Level   Level                  `json:"level"`
+	Module  string                 `json:"module"`
+	Message string                 `json:"message"`
+	Fields  map[string]interface{} `json:"fields,omitempty"`
+}
+
+//bufferSize is the number of entries kept by each logger
+const bufferSize = 500
+
+//Logger writes the messages of a module at or above
+//its level to the standard logger, with the module
+//prefix, and into a ring buffer for viewing in the UI
+type Logger struct {
+	module  string
+	mut     sync.Mutex
+	level   Level
+	entries []Entry
+	next    int
+	subs    map[chan Entry]bool
+	closed  bool
+}
+
+func New(module string) *Logger {
+	return &Logger{
+		module:  module,
+		level:   Info,
+		entries: make([]Entry, 0, bufferSize),
+		subs:    map[chan Entry]bool{},
+	}
+}
+
+//Level returns the minimum level written
+func (l *Logger) Level() Level {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	return l.level
+}
+
+//SetLevel changes the minimum level written
+func (l *Logger) SetLevel(level Level) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	l.level = level
+}
+
+//Debug writes msg with key/value pairs at the debug level
+func (l *Logger) Debug(msg string, kv ...interface{}) {
+	l.write(Debug, msg, kv)
+}
+
+//Info writes msg with key/value pairs at the info level
+func (l *Logger) Info(msg string, kv ...interface{}) {
+	l.write(Info, msg, kv)
+}
+
+//Warn writes msg with key/value pairs at the warn level
+func (l *Logger) Warn(msg string, kv ...interface{}) {
+	l.write(Warn, msg, kv)
+}
+
+//Error writes msg with key/value pairs at the error level
+func (l *Logger) Error(msg string, kv ...interface{}) {
+	l.write(Error, msg, kv)
+}
+
+func (l *Logger) Debugf(format string, args ...interface{}) {
+	l.write(Debug, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Infof(format string, args ...interface{}) {
+	l.write(Info, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Warnf(format string, args ...interface{}) {
+	l.write(Warn, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) Errorf(format string, args ...interface{}) {
+	l.write(Error, fmt.Sprintf(format, args...), nil)
+}
+
+//Printf writes at the info level
+func (l *Logger) Printf(format string, args ...interface{}) {
+	l.write(Info, fmt.Sprintf(format, args...), nil)
+}
+
+func (l *Logger) write(level Level, msg string, kv []interface{}) {
+	msg = strings.TrimSuffix(msg, "\n")
+	fields := pairs(kv)
+	//modules constructed without a logger
+	if l == nil {
+		emit(&Entry{Time: time.Now(), Level: level, Message: msg}, fields)
+		return
+	}
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	if level < l.level {
+		return
+	}
+	e := Entry{Time: time.Now(), Level: level, Module: l.module, Message: msg}
+	if len(fields) > 0 {
+		e.Fields = map[string]interface{}{}
+		for _, f := range fields {
+			e.Fields[f.key] = f.value
+		}
+	}
+	emit(&e, fields)
+	if len(l.entries) < bufferSize {
+		l.entries = append(l.entries, e)
+	} else {
+		l.entries[l.next] = e
+	}
+	l.next = (l.next + 1) % bufferSize
+	for c := range l.subs {
+		select {
+		case c <- e:
+		default:
+		}
+	}
+}
+
+//Entries returns the buffered entries, oldest first
+func (l *Logger) Entries() []Entry {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	entries := make([]Entry, 0, len(l.entries))
+	if len(l.entries) == bufferSize {
+		entries = append(entries, l.entries[l.next:]...)
+		entries = append(entries, l.entries[:l.next]...)
+	} else {
+		entries = append(entries, l.entries...)
+	}
+	return entries
+}
+
+//Subscribe receives new entries until cancelled or closed.
+//Entries are dropped while the subscriber is falling behind.
+func (l *Logger) Subscribe() (<-chan Entry, func()) {
+	c := make(chan Entry, 64)
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	if l.closed {
+		close(c)
+	} else {
+		l.subs[c] = true
+	}
+	return c, func() {
+		l.mut.Lock()
+		defer l.mut.Unlock()
+		if l.subs[c] {
+			delete(l.subs, c)
+			close(c)
+		}
+	}
+}
+
+//Close ends all subscriptions, allowing
+//streaming clients to disconnect
+func (l *Logger) Close() {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	l.closed = true
+	for c := range l.subs {
+		delete(l.subs, c)
+		close(c)
+	}
+}
+
+//ServeHTTP serves the buffered entries at or above ?level=,
+//or streams new entries as server-sent events when
+//requested with Accept: text/event-stream
+func (l *Logger) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	min := Debug
+	if s := r.URL.Query().Get("level"); s != "" {
+		var err error
+		if min, err = ParseLevel(s); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		l.stream(w, r, min)
+		return
+	}
+	entries := []Entry{}
+	for _, e := range l.Entries() {
+		if e.Level >= min {
+			entries = append(entries, e)
+		}
+	}
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+func (l *Logger) stream(w http.ResponseWriter, r *http.Request, min Level) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+	entries, cancel := l.Subscribe()
+	defer cancel()
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	ping := time.NewTicker(30 * time.Second)
+	defer ping.Stop()
+	for {
+		select {
+		case e, ok := <-entries:
+			if !ok {
+				return
+			}
+			if e.Level < min {
+				continue
+			}
+			b, _ := json.Marshal(e)
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Level, b)
+		case <-ping.C:
+			fmt.Fprint(w, ": ping\n\n")
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
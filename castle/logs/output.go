@@ -0,0 +1,169 @@
+package logs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//Format is the encoding of log output
+type Format int
+
+const (
+	//Text writes lines of "<time> <level> [<module>] <message> <key>=<value>..."
+	Text Format = iota
+	//JSON writes one object per line, with fields alongside
+	//the time, level, module and msg properties
+	JSON
+)
+
+var formatNames = []string{"text", "json"}
+
+func (f Format) String() string {
+	if f < Text || f > JSON {
+		return "unknown"
+	}
+	return formatNames[f]
+}
+
+//ParseFormat parses the name of a format, defaulting to text
+func ParseFormat(s string) (Format, error) {
+	if s == "" {
+		return Text, nil
+	}
+	for i, name := range formatNames {
+		if strings.EqualFold(s, name) {
+			return Format(i), nil
+		}
+	}
+	return Text, fmt.Errorf("invalid log format: %s (expected one of %s)", s, strings.Join(formatNames, ", "))
+}
+
+//output is shared by all loggers
+var output = struct {
+	sync.Mutex
+	w      io.Writer
+	format Format
+}{w: os.Stderr}
+
+func init() {
+	//dependencies log through the standard
+	//logger, capture them as info entries
+	log.SetFlags(0)
+	log.SetOutput(stdWriter{})
+}
+
+//SetFormat changes the format of all log output
+func SetFormat(f Format) {
+	output.Lock()
+	defer output.Unlock()
+	output.format = f
+}
+
+//SetOutput changes the destination of all log output
+func SetOutput(w io.Writer) {
+	output.Lock()
+	defer output.Unlock()
+	output.w = w
+}
+
+//stdWriter receives lines from the standard logger
+type stdWriter struct{}
+
+func (stdWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	emit(&Entry{Time: time.Now(), Level: Info, Message: msg}, nil)
+	return len(p), nil
+}
+
+//field is a single key/value pair, kept
+//in order for writing
+type field struct {
+	key   string
+	value interface{}
+}
+
+//pairs converts alternating keys and values into fields,
+//errors and stringers are converted into their strings
+func pairs(kv []interface{}) []field {
+	fields := make([]field, 0, (len(kv)+1)/2)
+	for i := 0; i < len(kv); i += 2 {
+		key := fmt.Sprint(kv[i])
+		var value interface{} = "(missing)"
+		if i+1 < len(kv) {
+			value = kv[i+1]
+		}
+		switch v := value.(type) {
+		case error:
+			value = v.Error()
+		case fmt.Stringer:
+			value = v.String()
+		}
+		fields = append(fields, field{key, value})
+	}
+	return fields
+}
+
+//emit writes e to the output in the current format
+func emit(e *Entry, fields []field) {
+	output.Lock()
+	defer output.Unlock()
+	b := bytes.Buffer{}
+	if output.format == JSON {
+		b.WriteString(`{"time":`)
+		writeJSON(&b, e.Time.Format(time.RFC3339Nano))
+		b.WriteString(`,"level":`)
+		writeJSON(&b, e.Level.String())
+		if e.Module != "" {
+			b.WriteString(`,"module":`)
+			writeJSON(&b, e.Module)
+		}
+		b.WriteString(`,"msg":`)
+		writeJSON(&b, e.Message)
+		for _, f := range fields {
+			b.WriteByte(',')
+			writeJSON(&b, f.key)
+			b.WriteByte(':')
+			writeJSON(&b, f.value)
+		}
+		b.WriteByte('}')
+	} else {
+		b.WriteString(e.Time.Format("2006/01/02 15:04:05 "))
+		b.WriteString(strings.ToUpper(e.Level.String()))
+		if e.Module != "" {
+			b.WriteString(" [" + e.Module + "]")
+		}
+		b.WriteString(" " + e.Message)
+		for _, f := range fields {
+			b.WriteString(" " + f.key + "=" + textValue(f.value))
+		}
+	}
+	b.WriteByte('\n')
+	output.w.Write(b.Bytes())
+}
+
+//writeJSON writes v, or its printed form when
+//it cannot be encoded
+func writeJSON(b *bytes.Buffer, v interface{}) {
+	j, err := json.Marshal(v)
+	if err != nil {
+		j, _ = json.Marshal(fmt.Sprint(v))
+	}
+	b.Write(j)
+}
+
+//textValue quotes values which would be ambiguous
+func textValue(v interface{}) string {
+	s := fmt.Sprint(v)
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,30 @@
+package logs
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//Wrap logs each request handled by next once it completes
+func (l *Logger) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t0 := time.Now()
+		rec := util.NewRecorder(w)
+		next.ServeHTTP(rec, r)
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+		l.Info(r.Method+" "+r.URL.Path,
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", rec.Status,
+			"size", rec.Size,
+			"duration", time.Since(t0).Round(time.Millisecond),
+			"ip", ip,
+		)
+	})
+}
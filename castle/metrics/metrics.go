@@ -0,0 +1,313 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//Registry holds all metrics, served in the
+//Prometheus text exposition format
+type Registry struct {
+	mut     sync.Mutex
+	metrics map[string]metric
+}
+
+func NewRegistry() *Registry {
+	return &Registry{metrics: map[string]metric{}}
+}
+
+type metric interface {
+	write(w *bufio.Writer)
+}
+
+//register adds m, or returns the metric already
+//registered under name
+func (r *Registry) register(name string, m metric) metric {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	if existing, ok := r.metrics[name]; ok {
+		return existing
+	}
+	r.metrics[name] = m
+	return m
+}
+
+//Counter registers a counter, labelled with labels.
+//Modules without a registry receive a nil counter,
+//which ignores all updates.
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	if r == nil {
+		return nil
+	}
+	c, ok := r.register(name, &Counter{newVec(name, help, "counter", labels)}).(*Counter)
+	if !ok {
+		panic("metric already registered with another type: " + name)
+	}
+	return c
+}
+
+//Gauge registers a gauge, labelled with labels
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	if r == nil {
+		return nil
+	}
+	g, ok := r.register(name, &Gauge{newVec(name, help, "gauge", labels)}).(*Gauge)
+	if !ok {
+		panic("metric already registered with another type: " + name)
+	}
+	return g
+}
+
+//GaugeFunc registers a gauge whose value is read from fn when scraped
+func (r *Registry) GaugeFunc(name, help string, fn func() float64) {
+	if r == nil {
+		return
+	}
+	r.register(name, &gaugeFunc{name: name, help: help, fn: fn})
+}
+
+//Histogram registers a histogram with the given upper bucket
+//bounds, in increasing order, labelled with labels
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if r == nil {
+		return nil
+	}
+	h, ok := r.register(name, &Histogram{newVec(name, help, "histogram", labels), buckets}).(*Histogram)
+	if !ok {
+		panic("metric already registered with another type: " + name)
+	}
+	return h
+}
+
+//ServeHTTP writes all metrics, sorted by name
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mut.Lock()
+	names := make([]string, 0, len(r.metrics))
+	for name := range r.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	metrics := make([]metric, len(names))
+	for i, name := range names {
+		metrics[i] = r.metrics[name]
+	}
+	r.mut.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	for _, m := range metrics {
+		m.write(bw)
+	}
+	bw.Flush()
+}
+
+//vec holds the values of a metric for each
+//combination of label values
+type vec struct {
+	name, help, kind string
+	labels           []string
+	mut              sync.Mutex
+	values           map[string]*value
+}
+
+type value struct {
+	labels  []string
+	sum     float64
+	count   uint64
+	buckets []uint64
+}
+
+func newVec(name, help, kind string, labels []string) *vec {
+	return &vec{name: name, help: help, kind: kind, labels: labels, values: map[string]*value{}}
+}
+
+//get finds the value for the label values, the vec must be locked
+func (v *vec) get(lvs []string) *value {
+	if len(lvs) != len(v.labels) {
+		panic(fmt.Sprintf("%s: expected %d label values, got %d", v.name, len(v.labels), len(lvs)))
+	}
+	key := strings.Join(lvs, "\xff")
+	val, ok := v.values[key]
+	if !ok {
+		val = &value{labels: append([]string{}, lvs...)}
+		v.values[key] = val
+	}
+	return val
+}
+
+//sorted returns the values ordered by their label values
+func (v *vec) sorted() []*value {
+	keys := make([]string, 0, len(v.values))
+	for k := range v.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	values := make([]*value, len(keys))
+	for i, k := range keys {
+		values[i] = v.values[k]
+	}
+	return values
+}
+
+func (v *vec) header(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", v.name, escape(v.help, false))
+	fmt.Fprintf(w, "# TYPE %s %s\n", v.name, v.kind)
+}
+
+//Counter is a value which only increases
+type Counter struct {
+	*vec
+}
+
+//Inc adds one to the counter with the label values
+func (c *Counter) Inc(lvs ...string) {
+	c.Add(1, lvs...)
+}
+
+//Add adds n, which must not be negative
+func (c *Counter) Add(n float64, lvs ...string) {
+	if c == nil || n < 0 {
+		return
+	}
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.get(lvs).sum += n
+}
+
+func (c *Counter) write(w *bufio.Writer) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.header(w)
+	for _, val := range c.sorted() {
+		sample(w, c.name, c.labels, val.labels, "", "", val.sum)
+	}
+}
+
+//Gauge is a value which may go up and down
+type Gauge struct {
+	*vec
+}
+
+//Set changes the gauge with the label values to n
+func (g *Gauge) Set(n float64, lvs ...string) {
+	if g == nil {
+		return
+	}
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	g.get(lvs).sum = n
+}
+
+//Add adds n to the gauge with the label values
+func (g *Gauge) Add(n float64, lvs ...string) {
+	if g == nil {
+		return
+	}
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	g.get(lvs).sum += n
+}
+
+func (g *Gauge) write(w *bufio.Writer) {
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	g.header(w)
+	for _, val := range g.sorted() {
+		sample(w, g.name, g.labels, val.labels, "", "", val.sum)
+	}
+}
+
+type gaugeFunc struct {
+	name, help string
+	fn         func() float64
+}
+
+func (g *gaugeFunc) write(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", g.name, escape(g.help, false))
+	fmt.Fprintf(w, "# TYPE %s gauge\n", g.name)
+	sample(w, g.name, nil, nil, "", "", g.fn())
+}
+
+//Histogram counts observations into buckets
+type Histogram struct {
+	*vec
+	bounds []float64
+}
+
+//Observe records n against the label values
+func (h *Histogram) Observe(n float64, lvs ...string) {
+	if h == nil {
+		return
+	}
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	val := h.get(lvs)
+	if val.buckets == nil {
+		val.buckets = make([]uint64, len(h.bounds))
+	}
+	for i, bound := range h.bounds {
+		if n <= bound {
+			val.buckets[i]++
+		}
+	}
+	val.sum += n
+	val.count++
+}
+
+func (h *Histogram) write(w *bufio.Writer) {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	h.header(w)
+	for _, val := range h.sorted() {
+		for i, bound := range h.bounds {
+			sample(w, h.name+"_bucket", h.labels, val.labels, "le", format(bound), float64(val.buckets[i]))
+		}
+		sample(w, h.name+"_bucket", h.labels, val.labels, "le", "+Inf", float64(val.count))
+		sample(w, h.name+"_sum", h.labels, val.labels, "", "", val.sum)
+		sample(w, h.name+"_count", h.labels, val.labels, "", "", float64(val.count))
+	}
+}
+
+//sample writes a single line, with an optional extra label
+func sample(w *bufio.Writer, name string, labels, lvs []string, extra, extraValue string, n float64) {
+	w.WriteString(name)
+	if len(labels) > 0 || extra != "" {
+		pairs := []string{}
+		for i, l := range labels {
+			pairs = append(pairs, l+`="`+escape(lvs[i], true)+`"`)
+		}
+		if extra != "" {
+			pairs = append(pairs, extra+`="`+extraValue+`"`)
+		}
+		w.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+	w.WriteString(" " + format(n) + "\n")
+}
+
+func format(n float64) string {
+	switch {
+	case math.IsInf(n, 1):
+		return "+Inf"
+	case math.IsInf(n, -1):
+		return "-Inf"
+	case math.IsNaN(n):
+		return "NaN"
+	}
+	return strconv.FormatFloat(n, 'g', -1, 64)
+}
+
+//escape escapes help text, and label values which
+//additionally escape quotes
+func escape(s string, quotes bool) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, "\n", `\n`, -1)
+	if quotes {
+		s = strings.Replace(s, `"`, `\"`, -1)
+	}
+	return s
+}
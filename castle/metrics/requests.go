@@ -0,0 +1,18 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//Wrap counts the requests handled by next, by method and status code
+func (r *Registry) Wrap(next http.Handler) http.Handler {
+	requests := r.Counter("castle_http_requests_total", "HTTP requests handled", "method", "code")
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rec := util.NewRecorder(w)
+		next.ServeHTTP(rec, req)
+		requests.Inc(req.Method, strconv.Itoa(rec.Status))
+	})
+}
@@ -1,38 +1,330 @@
 package auth
 
-import "encoding/json"
-import "github.com/jpillora/cookieauth"
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
 
-func New() *Auth {
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/util"
+	goji "goji.io"
+	"goji.io/pat"
+)
+
+//loginInterval limits successful login events, since
+//clients like curl present credentials on every request
+const loginInterval = time.Hour
+
+var errUnauthorized = errors.New("Unauthorized")
+
+func New(db *bolt.DB) *Auth {
 	a := &Auth{
-		CookieAuth: cookieauth.New(),
+		db:       db,
+		users:    map[string]*User{},
+		tokens:   map[string]*Token{},
+		totp:     map[string]*enrollment{},
+		sessions: map[string]*session{},
+		logins:   map[string]time.Time{},
+		//failed and in progress logins
+		attempts:     map[string]int{},
+		ipFailures:   map[string]*failures{},
+		userFailures: map[string]*failures{},
+	}
+	if err := a.loadUsers(); err != nil {
+		a.log.Error("failed to load users", "err", err)
+	}
+	if err := a.loadTokens(); err != nil {
+		a.log.Error("failed to load tokens", "err", err)
+	}
+	if err := a.loadEnrollments(); err != nil {
+		a.log.Error("failed to load totp enrollments", "err", err)
 	}
 	return a
 }
 
 type Auth struct {
-	CookieAuth *cookieauth.CookieAuth
-	settings   struct {
-		User string `json:"user"`
-		Pass string `json:"pass"`
-	}
+	db           *bolt.DB
+	bus          *events.Bus
+	log          *logs.Logger
+	mut          sync.Mutex
+	users        map[string]*User
+	tokens       map[string]*Token
+	totp         map[string]*enrollment
+	sessions     map[string]*session
+	logins       map[string]time.Time
+	ipFailures   map[string]*failures
+	userFailures map[string]*failures
+	attempts     map[string]int
+	allowlist    []*net.IPNet
+	pending      chan interface{}
+	update       func(json.RawMessage, string) error
+	settings     settings
+}
+
+type settings struct {
+	User       string        `json:"user" help:"admin username"`
+	Pass       string        `json:"pass" help:"admin password, stored hashed" secret:"true"`
+	Attempts   int           `json:"attempts" help:"failed logins allowed before locking out" default:"5"`
+	Lockout    util.Duration `json:"lockout" help:"first lockout, doubling with each further failure" default:"1m"`
+	MaxLockout util.Duration `json:"maxLockout" help:"longest lockout" default:"1h"`
+	Allowlist  []string      `json:"allowlist" help:"addresses and networks never locked out, such as the LAN when not behind a proxy"`
 }
 
 func (a *Auth) ID() string {
 	return "auth"
 }
 
+func (a *Auth) SetLogger(l *logs.Logger) {
+	a.log = l
+}
+
+func (a *Auth) SetBus(bus *events.Bus) {
+	a.bus = bus
+}
+
+func (a *Auth) RegisterRoutes(mux *goji.Mux) {
+	mux.Handle(pat.Get("/me"), http.HandlerFunc(a.me))
+	mux.Handle(pat.Put("/password"), http.HandlerFunc(a.changePassword))
+	mux.Handle(pat.Get("/users"), http.HandlerFunc(a.listUsers))
+	mux.Handle(pat.Put("/users/:name"), http.HandlerFunc(a.putUser))
+	mux.Handle(pat.Delete("/users/:name"), http.HandlerFunc(a.removeUser))
+	mux.Handle(pat.Delete("/users/:name/totp"), http.HandlerFunc(a.resetTOTP))
+	mux.Handle(pat.Post("/totp"), http.HandlerFunc(a.enrollTOTP))
+	mux.Handle(pat.Put("/totp"), http.HandlerFunc(a.confirmTOTP))
+	mux.Handle(pat.Delete("/totp"), http.HandlerFunc(a.disableTOTP))
+	mux.Handle(pat.Get("/tokens"), http.HandlerFunc(a.listTokens))
+	mux.Handle(pat.Post("/tokens"), http.HandlerFunc(a.createToken))
+	mux.Handle(pat.Delete("/tokens/:id"), http.HandlerFunc(a.revokeToken))
+	mux.Handle(pat.Delete("/lockouts"), http.HandlerFunc(a.unlock))
+}
+
+//Wrap requires non-public requests to next be made by a user, identified
+//by session cookie, basic auth credentials or bearer token, whose
+//role allows the request. Login events are published as credentials
+//are checked, and repeated failures are locked out.
+func (a *Auth) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isPublic(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+		user, role, token, err := a.authenticate(w, r)
+		if l, ok := err.(*lockedOut); ok {
+			w.Header().Set("Retry-After", l.retryAfter())
+			http.Error(w, l.Error(), http.StatusTooManyRequests)
+			return
+		} else if err != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="castlebot"`)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		if required := requiredRole(r); !role.allows(required) {
+			http.Error(w, "Forbidden: requires the "+string(required)+" role", http.StatusForbidden)
+			return
+		}
+		if token != nil && !token.allows(r.URL.Path) {
+			http.Error(w, "Forbidden: token is not scoped to "+r.URL.Path, http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, util.WithUser(r, user))
+	})
+}
+
+//authenticate identifies the user of r. Passwords are verified
+//without holding the lock, since hashing is deliberately slow.
+func (a *Auth) authenticate(w http.ResponseWriter, r *http.Request) (string, Role, *Token, error) {
+	ip := remoteIP(r)
+	a.mut.Lock()
+	//no accounts, no auth
+	if a.open() {
+		a.mut.Unlock()
+		return "", Admin, nil, nil
+	}
+	if strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		defer a.mut.Unlock()
+		if err := a.checkLocked(ip, ""); err != nil {
+			return "", "", nil, err
+		}
+		t, ok := a.bearerToken(r)
+		if !ok {
+			a.login("token", false, r)
+			a.failed(ip, "")
+			return "", "", nil, errUnauthorized
+		}
+		a.login(t.user(), true, r)
+		a.succeeded(ip, "")
+		return t.user(), t.Role, t, nil
+	}
+	if user, ok := a.sessionUser(r); ok {
+		if role, ok := a.roleOf(user); ok {
+			a.mut.Unlock()
+			return user, role, nil, nil
+		}
+	}
+	user, pass, ok := r.BasicAuth()
+	if !ok {
+		a.mut.Unlock()
+		return "", "", nil, errUnauthorized
+	}
+	if err := a.reserve(ip, user); err != nil {
+		a.mut.Unlock()
+		return "", "", nil, err
+	}
+	hash, role, known := a.credentials(user)
+	needsCode := a.enrolled(user)
+	a.mut.Unlock()
+	if !known {
+		hash = dummyHash()
+	}
+	code := ""
+	if needsCode {
+		pass, code = splitCode(r, pass)
+	}
+	ok = verifyPassword(hash, pass) && known
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	a.release(ip, user)
+	if ok && needsCode {
+		ok = a.checkCode(user, code)
+	}
+	a.login(user, ok, r)
+	if !ok {
+		a.failed(ip, user)
+		if needsCode && code == "" {
+			return "", "", nil, errCodeRequired
+		}
+		return "", "", nil, errUnauthorized
+	}
+	a.succeeded(ip, user)
+	if browserLogin(r) {
+		a.startSession(w, r, user)
+	}
+	return user, role, nil, nil
+}
+
+//open is true while there are no accounts. Auth must be locked.
+func (a *Auth) open() bool {
+	return a.settings.User == "" && a.settings.Pass == "" && len(a.users) == 0
+}
+
+//roleOf finds the role of user. Auth must be locked.
+func (a *Auth) roleOf(user string) (Role, bool) {
+	if a.open() {
+		return Admin, true
+	}
+	if user != "" && user == a.settings.User {
+		return Admin, true
+	}
+	if u, ok := a.users[user]; ok {
+		return u.Role, true
+	}
+	return "", false
+}
+
+//credentials finds the password hash and role
+//of user. Auth must be locked.
+func (a *Auth) credentials(user string) (string, Role, bool) {
+	if a.settings.User != "" && user == a.settings.User {
+		return a.settings.Pass, Admin, true
+	}
+	if u, ok := a.users[user]; ok {
+		return u.Pass, u.Role, true
+	}
+	return "", "", false
+}
+
+//login publishes a login event. Auth must be locked.
+func (a *Auth) login(user string, success bool, r *http.Request) {
+	ip := remoteIP(r)
+	if success {
+		key := user + "@" + ip
+		if t, ok := a.logins[key]; ok && time.Since(t) < loginInterval {
+			return
+		}
+		a.logins[key] = time.Now()
+	}
+	a.bus.Publish(events.Event{
+		Module: a.ID(),
+		Type:   events.Login,
+		Data:   events.LoginData{User: user, IP: ip, Success: success},
+	})
+}
+
 func (a *Auth) Get() interface{} {
 	return &a.settings
 }
 
 func (a *Auth) Set(j json.RawMessage) error {
-	if j != nil {
-		if err := json.Unmarshal(j, &a.settings); err != nil {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	settings, allowlist, err := a.parse(j)
+	if err != nil {
+		return err
+	}
+	if !isHash(settings.Pass) {
+		hash, err := hashPassword(settings.Pass)
+		if err != nil {
 			return err
 		}
+		settings.Pass = hash
 	}
-	a.CookieAuth.SetUserPass(a.settings.User, a.settings.Pass)
-	//do stuff
+	//the admin account changed, log the admin in again
+	if settings.User != a.settings.User || settings.Pass != a.settings.Pass {
+		for _, user := range []string{a.settings.User, settings.User} {
+			a.endSessions(user)
+			for key := range a.logins {
+				if strings.HasPrefix(key, user+"@") {
+					delete(a.logins, key)
+				}
+			}
+		}
+	}
+	a.settings = settings
+	a.allowlist = allowlist
 	return nil
 }
+
+//Validate checks j without applying it
+func (a *Auth) Validate(j json.RawMessage) error {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	_, _, err := a.parse(j)
+	return err
+}
+
+//parse decodes j over a copy of the current settings, returning
+//them along with the parsed allowlist. Auth must be locked.
+func (a *Auth) parse(j json.RawMessage) (settings, []*net.IPNet, error) {
+	s := a.settings
+	if j != nil {
+		if err := json.Unmarshal(j, &s); err != nil {
+			return s, nil, err
+		}
+	}
+	if s.Attempts <= 0 {
+		s.Attempts = 5
+	}
+	if s.Lockout <= 0 {
+		s.Lockout = util.Duration(time.Minute)
+	}
+	if s.MaxLockout < s.Lockout {
+		s.MaxLockout = util.Duration(time.Hour)
+		if s.MaxLockout < s.Lockout {
+			s.MaxLockout = s.Lockout
+		}
+	}
+	if _, ok := a.users[s.User]; ok && s.User != a.settings.User {
+		return s, nil, errors.New("User is used by an account in the users list")
+	}
+	allowlist, err := parseAllowlist(s.Allowlist)
+	if err != nil {
+		return s, nil, err
+	}
+	return s, allowlist, nil
+}
@@ -0,0 +1,321 @@
+package auth
+
+import (
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jpillora/castlebot/castle/events"
+)
+
+const (
+	//failureTTL is how long failures are remembered
+	//after the last failure, once unlocked
+	failureTTL = 24 * time.Hour
+	//maxTracked bounds the addresses and users tracked
+	maxTracked = 10000
+)
+
+//lanAllowlist is loopback, private and link-local addresses, the
+//former default allowlist. Behind a local reverse proxy every client
+//appears to be on the LAN, so nothing is allowlisted by default.
+var lanAllowlist = []string{
+	"127.0.0.0/8", "10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "169.254.0.0/16",
+	"::1/128", "fc00::/7", "fe80::/10",
+}
+
+//failures of an address or user
+type failures struct {
+	count int
+	last  time.Time
+	until time.Time
+}
+
+//Lock is an address or user which may not log in until Until
+type Lock struct {
+	Kind     string    `json:"kind"`
+	Key      string    `json:"key"`
+	Failures int       `json:"failures"`
+	Until    time.Time `json:"until"`
+}
+
+//lockedOut is returned while the address or user of a request is locked
+type lockedOut struct {
+	until time.Time
+}
+
+func (l *lockedOut) Error() string {
+	return "Too many failed logins, try again in " + l.wait().String()
+}
+
+func (l *lockedOut) wait() time.Duration {
+	return time.Until(l.until).Round(time.Second)
+}
+
+//retryAfter is the Retry-After header value, in whole seconds
+func (l *lockedOut) retryAfter() string {
+	return strconv.Itoa(int(time.Until(l.until)/time.Second) + 1)
+}
+
+func remoteIP(r *http.Request) string {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return ip
+}
+
+//allowlisted reports whether ip is never locked out. Auth must be locked.
+func (a *Auth) allowlisted(ip string) bool {
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, n := range a.allowlist {
+		if n.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+//parseAllowlist parses the allowlist settings, which
+//are networks in CIDR notation or single addresses
+func parseAllowlist(list []string) ([]*net.IPNet, error) {
+	nets := []*net.IPNet{}
+	for _, s := range list {
+		if ip := net.ParseIP(s); ip != nil {
+			bits := 8 * len(ip.To16())
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+//checkLocked returns a lockedOut error while ip or user,
+//if given, are locked out. Auth must be locked.
+func (a *Auth) checkLocked(ip, user string) error {
+	if a.allowlisted(ip) {
+		return nil
+	}
+	now := time.Now()
+	var until time.Time
+	if f, ok := a.ipFailures[ip]; ok && f.until.After(until) {
+		until = f.until
+	}
+	if f, ok := a.userFailures[user]; ok && user != "" && f.until.After(until) {
+		until = f.until
+	}
+	if until.After(now) {
+		return &lockedOut{until: until}
+	}
+	return nil
+}
+
+//reserve counts a login from ip as user in progress, before its
+//credentials are verified without holding the lock, so concurrent
+//attempts cannot exceed those allowed. Once a lockout has expired,
+//one attempt at a time is allowed. Auth must be locked.
+func (a *Auth) reserve(ip, user string) error {
+	if err := a.checkLocked(ip, user); err != nil {
+		return err
+	}
+	if a.allowlisted(ip) {
+		return nil
+	}
+	now := time.Now()
+	if a.attempting(a.ipFailures, "ip:"+ip, ip, now) ||
+		(user != "" && a.attempting(a.userFailures, "user:"+user, user, now)) {
+		return &lockedOut{until: now.Add(time.Second)}
+	}
+	a.attempts["ip:"+ip]++
+	if user != "" {
+		a.attempts["user:"+user]++
+	}
+	return nil
+}
+
+//attempting reports whether the attempts in progress of key
+//use up those remaining before a lockout. Auth must be locked.
+func (a *Auth) attempting(tracked map[string]*failures, attempt, key string, now time.Time) bool {
+	count := 0
+	if f, ok := tracked[key]; ok && !(now.After(f.until) && now.Sub(f.last) > failureTTL) {
+		count = f.count
+	}
+	remaining := a.settings.Attempts - count
+	if remaining < 1 {
+		remaining = 1
+	}
+	return a.attempts[attempt] >= remaining
+}
+
+//release ends a login reserved by reserve, which must then
+//be recorded with failed or succeeded. Auth must be locked.
+func (a *Auth) release(ip, user string) {
+	if a.allowlisted(ip) {
+		return
+	}
+	for _, key := range []string{"ip:" + ip, "user:" + user} {
+		if n := a.attempts[key]; n > 1 {
+			a.attempts[key] = n - 1
+		} else {
+			delete(a.attempts, key)
+		}
+	}
+}
+
+//failed records a failed login from ip as user, if given, locking
+//out either once they exceed the allowed attempts. Auth must be locked.
+func (a *Auth) failed(ip, user string) {
+	if a.allowlisted(ip) {
+		return
+	}
+	a.fail("ip", a.ipFailures, ip)
+	if user != "" {
+		a.fail("user", a.userFailures, user)
+	}
+	a.push()
+}
+
+func (a *Auth) fail(kind string, tracked map[string]*failures, key string) {
+	now := time.Now()
+	f, ok := tracked[key]
+	if !ok || (now.After(f.until) && now.Sub(f.last) > failureTTL) {
+		if len(tracked) >= maxTracked {
+			forget(tracked, now)
+		}
+		f = &failures{}
+		tracked[key] = f
+	}
+	f.count++
+	f.last = now
+	over := f.count - a.settings.Attempts
+	if over < 0 {
+		return
+	}
+	//double the lockout with each further failure
+	d := a.settings.Lockout.D()
+	for i := 0; i < over && d < a.settings.MaxLockout.D(); i++ {
+		d *= 2
+	}
+	if max := a.settings.MaxLockout.D(); d > max {
+		d = max
+	}
+	f.until = now.Add(d)
+	a.log.Warn("locked out", kind, key, "failures", f.count, "for", d)
+	data := events.LockoutData{Failures: f.count, Until: f.until}
+	if kind == "ip" {
+		data.IP = key
+	} else {
+		data.User = key
+	}
+	a.bus.Publish(events.Event{
+		Module: a.ID(),
+		Type:   events.Lockout,
+		Data:   data,
+	})
+}
+
+//forget drops unlocked failures older than the failure TTL,
+//or else the oldest unlocked failures, to make room
+func forget(tracked map[string]*failures, now time.Time) {
+	oldest := ""
+	for key, f := range tracked {
+		if now.Before(f.until) {
+			continue
+		}
+		if now.Sub(f.last) > failureTTL {
+			delete(tracked, key)
+		} else if oldest == "" || f.last.Before(tracked[oldest].last) {
+			oldest = key
+		}
+	}
+	if len(tracked) >= maxTracked && oldest != "" {
+		delete(tracked, oldest)
+	}
+}
+
+//succeeded clears the failures of ip and user. Auth must be locked.
+func (a *Auth) succeeded(ip, user string) {
+	_, ipFailed := a.ipFailures[ip]
+	_, userFailed := a.userFailures[user]
+	if !ipFailed && !userFailed {
+		return
+	}
+	delete(a.ipFailures, ip)
+	delete(a.userFailures, user)
+	a.push()
+}
+
+//locks lists the current locks. Auth must be locked.
+func (a *Auth) locks() []Lock {
+	now := time.Now()
+	locks := []Lock{}
+	for kind, tracked := range map[string]map[string]*failures{"ip": a.ipFailures, "user": a.userFailures} {
+		for key, f := range tracked {
+			if f.until.After(now) {
+				locks = append(locks, Lock{Kind: kind, Key: key, Failures: f.count, Until: f.until})
+			}
+		}
+	}
+	sort.Slice(locks, func(i, j int) bool {
+		return locks[i].Until.After(locks[j].Until)
+	})
+	return locks
+}
+
+//unlock clears all failures, ending all lockouts
+func (a *Auth) unlock(w http.ResponseWriter, r *http.Request) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	a.ipFailures = map[string]*failures{}
+	a.userFailures = map[string]*failures{}
+	a.push()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *Auth) Status(updates chan interface{}) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	//statuses are sent without holding the lock
+	a.pending = make(chan interface{}, 1)
+	go func(pending chan interface{}) {
+		for status := range pending {
+			updates <- status
+		}
+	}(a.pending)
+	a.push()
+}
+
+//push queues a copy of the status, replacing any not
+//yet sent, so it never blocks. Auth must be locked.
+func (a *Auth) push() {
+	if a.pending == nil {
+		return
+	}
+	status := struct {
+		Failures  int    `json:"failures"`
+		LockedOut int    `json:"lockedOut"`
+		Locks     []Lock `json:"locks"`
+	}{Locks: a.locks()}
+	for _, f := range a.ipFailures {
+		status.Failures += f.count
+	}
+	status.LockedOut = len(status.Locks)
+	select {
+	case <-a.pending:
+	default:
+	}
+	a.pending <- &status
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//testAuth is an Auth without a database, allowing
+//3 attempts then locking out for 1m, up to 4m
+func testAuth(allowlist ...string) *Auth {
+	a := &Auth{
+		log:          logs.New("auth"),
+		attempts:     map[string]int{},
+		ipFailures:   map[string]*failures{},
+		userFailures: map[string]*failures{},
+	}
+	a.settings.Attempts = 3
+	a.settings.Lockout = util.Duration(time.Minute)
+	a.settings.MaxLockout = util.Duration(4 * time.Minute)
+	a.allowlist, _ = parseAllowlist(allowlist)
+	return a
+}
+
+func TestLockoutBackoff(t *testing.T) {
+	a := testAuth()
+	for i, lockout := range []time.Duration{
+		0, 0, time.Minute, 2 * time.Minute, 4 * time.Minute, 4 * time.Minute,
+	} {
+		a.failed("1.2.3.4", "bob")
+		for _, f := range []*failures{a.ipFailures["1.2.3.4"], a.userFailures["bob"]} {
+			if f.count != i+1 {
+				t.Fatalf("failure %d: counted %d", i+1, f.count)
+			}
+			if lockout == 0 {
+				if !f.until.IsZero() {
+					t.Errorf("failure %d: locked out", i+1)
+				}
+			} else if d := f.until.Sub(f.last); d != lockout {
+				t.Errorf("failure %d: locked out for %s, want %s", i+1, d, lockout)
+			}
+		}
+		err := a.checkLocked("1.2.3.4", "bob")
+		if _, locked := err.(*lockedOut); locked != (lockout > 0) {
+			t.Errorf("failure %d: checkLocked = %v", i+1, err)
+		}
+	}
+	//either the address or user locks out
+	if err := a.checkLocked("5.6.7.8", "bob"); err == nil {
+		t.Error("user not locked out from another address")
+	}
+	if err := a.checkLocked("1.2.3.4", "alice"); err == nil {
+		t.Error("address not locked out as another user")
+	}
+	if err := a.checkLocked("5.6.7.8", "alice"); err != nil {
+		t.Errorf("other address and user locked out: %s", err)
+	}
+	//success clears failures
+	a.succeeded("1.2.3.4", "bob")
+	if err := a.checkLocked("1.2.3.4", "bob"); err != nil {
+		t.Errorf("locked out after success: %s", err)
+	}
+}
+
+func TestLockoutExpired(t *testing.T) {
+	a := testAuth()
+	for i := 0; i < 4; i++ {
+		a.failed("1.2.3.4", "")
+	}
+	//once the lockout expires, one attempt at a time is allowed,
+	//and further failures lock out for longer
+	f := a.ipFailures["1.2.3.4"]
+	f.until = time.Now().Add(-time.Second)
+	if err := a.reserve("1.2.3.4", ""); err != nil {
+		t.Fatalf("reserve after lockout: %s", err)
+	}
+	if err := a.reserve("1.2.3.4", ""); err == nil {
+		t.Error("second reserve after lockout allowed")
+	}
+	a.release("1.2.3.4", "")
+	a.failed("1.2.3.4", "")
+	if d := f.until.Sub(f.last); d != 4*time.Minute {
+		t.Errorf("locked out for %s, want %s", d, 4*time.Minute)
+	}
+	//failures are forgotten after their TTL
+	f.until = time.Now().Add(-failureTTL - time.Second)
+	f.last = f.until
+	a.failed("1.2.3.4", "")
+	if n := a.ipFailures["1.2.3.4"].count; n != 1 {
+		t.Errorf("counted %d failures after the TTL, want 1", n)
+	}
+}
+
+func TestReserveConcurrent(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		allowlist []string
+		failures  int
+		reserved  int
+	}{
+		{"fresh", nil, 0, 3},
+		{"after failures", nil, 2, 1},
+		{"allowlisted", []string{"10.0.0.0/8"}, 2, 20},
+	} {
+		a := testAuth(test.allowlist...)
+		for i := 0; i < test.failures; i++ {
+			a.failed("10.0.0.1", "bob")
+		}
+		//logins are verified concurrently,
+		//only reserving under the lock
+		reserved := 0
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				a.mut.Lock()
+				defer a.mut.Unlock()
+				if a.reserve("10.0.0.1", "bob") == nil {
+					reserved++
+				}
+			}()
+		}
+		wg.Wait()
+		if reserved != test.reserved {
+			t.Errorf("%s: reserved %d, want %d", test.name, reserved, test.reserved)
+		}
+		//released attempts may be retried
+		if test.allowlist == nil {
+			a.release("10.0.0.1", "bob")
+			if err := a.reserve("10.0.0.1", "bob"); err != nil {
+				t.Errorf("%s: reserve after release: %s", test.name, err)
+			}
+		}
+	}
+}
+
+func TestParseAllowlist(t *testing.T) {
+	nets, err := parseAllowlist([]string{"10.0.0.0/8", "192.168.1.5", "::1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := testAuth()
+	a.allowlist = nets
+	for ip, allowed := range map[string]bool{
+		"10.1.2.3":    true,
+		"192.168.1.5": true,
+		"192.168.1.6": false,
+		"::1":         true,
+		"::2":         false,
+		"8.8.8.8":     false,
+		"invalid":     false,
+	} {
+		if a.allowlisted(ip) != allowed {
+			t.Errorf("allowlisted(%s) = %v, want %v", ip, !allowed, allowed)
+		}
+	}
+	for _, s := range []string{"10.0.0.0/33", "nope", "1.2.3"} {
+		if _, err := parseAllowlist([]string{s}); err == nil {
+			t.Errorf("parseAllowlist(%s) expected an error", s)
+		}
+	}
+	//nothing is allowlisted by default
+	if a := testAuth(); a.allowlisted("127.0.0.1") {
+		t.Error("loopback allowlisted by default")
+	}
+}
@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/jpillora/castlebot/castle/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//hashCost is the bcrypt cost of new password hashes
+const hashCost = bcrypt.DefaultCost
+
+var errIncorrectPassword = errors.New("Incorrect password")
+
+//isHash reports whether pass is already a bcrypt hash,
+//rather than a plaintext password awaiting migration
+func isHash(pass string) bool {
+	_, err := bcrypt.Cost([]byte(pass))
+	return err == nil
+}
+
+//hashPassword hashes pass, the empty password stays empty
+func hashPassword(pass string) (string, error) {
+	if pass == "" {
+		return "", nil
+	}
+	b, err := bcrypt.GenerateFromPassword([]byte(pass), hashCost)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+//verifyPassword reports whether pass matches hash
+func verifyPassword(hash, pass string) bool {
+	if hash == "" {
+		return pass == ""
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(pass)) == nil
+}
+
+var dummy struct {
+	once sync.Once
+	hash string
+}
+
+//dummyHash is verified against for unknown users, so
+//they take as long to reject as incorrect passwords
+func dummyHash() string {
+	dummy.once.Do(func() {
+		dummy.hash, _ = hashPassword("castlebot")
+	})
+	return dummy.hash
+}
+
+//Migrations hashes the plaintext admin password
+//of settings stored before passwords were hashed
+func (a *Auth) Migrations() []func(map[string]interface{}) error {
+	return []func(map[string]interface{}) error{
+		//v1: hashed password
+		hashPass,
+		//v2: the LAN is no longer allowlisted by default
+		func(s map[string]interface{}) error {
+			list, _ := s["allowlist"].([]interface{})
+			if len(list) != len(lanAllowlist) {
+				return nil
+			}
+			for i, n := range list {
+				if n != lanAllowlist[i] {
+					return nil
+				}
+			}
+			delete(s, "allowlist")
+			return nil
+		},
+	}
+}
+
+//hashPass hashes the plaintext admin password within settings s
+func hashPass(s map[string]interface{}) error {
+	pass, _ := s["pass"].(string)
+	if isHash(pass) {
+		return nil
+	}
+	hash, err := hashPassword(pass)
+	if err != nil {
+		return err
+	}
+	s["pass"] = hash
+	return nil
+}
+
+//HashSettings hashes the plaintext admin password within the
+//auth settings j, for settings stored while the bot is stopped
+func HashSettings(j []byte) ([]byte, error) {
+	s := map[string]interface{}{}
+	if err := json.Unmarshal(j, &s); err != nil {
+		return nil, err
+	}
+	if err := hashPass(s); err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+func (a *Auth) SetUpdater(update func(json.RawMessage, string) error) {
+	a.update = update
+}
+
+//changePassword changes the password of the user making
+//the request, who must provide their current password
+func (a *Auth) changePassword(w http.ResponseWriter, r *http.Request) {
+	change := struct {
+		Old string `json:"old"`
+		New string `json:"new"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&change); err != nil {
+		http.Error(w, "Expecting valid JSON", http.StatusBadRequest)
+		return
+	}
+	if change.New == "" {
+		http.Error(w, "New password is required", http.StatusBadRequest)
+		return
+	}
+	user := util.RequestUser(r)
+	if user == "" {
+		http.Error(w, "No account to change, add one in the auth settings", http.StatusBadRequest)
+		return
+	}
+	ip := remoteIP(r)
+	a.mut.Lock()
+	err := a.reserve(ip, user)
+	a.mut.Unlock()
+	if l, ok := err.(*lockedOut); ok {
+		w.Header().Set("Retry-After", l.retryAfter())
+		http.Error(w, l.Error(), http.StatusTooManyRequests)
+		return
+	}
+	status, err := a.setPassword(user, change.Old, change.New)
+	a.mut.Lock()
+	a.release(ip, user)
+	if err == errIncorrectPassword {
+		a.login(user, false, r)
+		a.failed(ip, user)
+	}
+	a.mut.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	a.log.Info("changed password", "user", user)
+	//other sessions have ended, continue this one
+	a.mut.Lock()
+	a.startSession(w, r, user)
+	a.mut.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *Auth) setPassword(user, old, pass string) (int, error) {
+	a.mut.Lock()
+	current, _, _ := a.credentials(user)
+	admin := user == a.settings.User
+	a.mut.Unlock()
+	if !verifyPassword(current, old) {
+		return http.StatusForbidden, errIncorrectPassword
+	}
+	hash, err := hashPassword(pass)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	//the admin account is stored with the auth settings
+	if admin {
+		a.mut.Lock()
+		settings := a.settings
+		a.mut.Unlock()
+		settings.Pass = hash
+		j, _ := json.Marshal(&settings)
+		if err := a.update(j, user); err != nil {
+			return http.StatusInternalServerError, err
+		}
+		return http.StatusOK, nil
+	}
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	u, ok := a.users[user]
+	if !ok {
+		return http.StatusNotFound, errors.New("User not found")
+	}
+	updated := *u
+	updated.Pass = hash
+	if err := a.saveUser(&updated); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	a.users[user] = &updated
+	a.endSessions(user)
+	return http.StatusOK, nil
+}
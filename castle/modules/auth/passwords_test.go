@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPasswords(t *testing.T) {
+	for _, pass := range []string{"", "hunter2", "with:colon"} {
+		hash, err := hashPassword(pass)
+		if err != nil {
+			t.Fatalf("hashPassword(%q): %s", pass, err)
+		}
+		if pass == "" {
+			//the empty password stays empty
+			if hash != "" {
+				t.Errorf("hashPassword(\"\") = %q", hash)
+			}
+		} else if !isHash(hash) || hash == pass {
+			t.Errorf("hashPassword(%q) = %q, not a hash", pass, hash)
+		}
+		if !verifyPassword(hash, pass) {
+			t.Errorf("verifyPassword(%q) failed", pass)
+		}
+		if verifyPassword(hash, pass+"x") {
+			t.Errorf("verifyPassword(%q) accepted %q", pass, pass+"x")
+		}
+	}
+	if isHash("hunter2") {
+		t.Error("plaintext password is a hash")
+	}
+}
+
+func TestHashSettings(t *testing.T) {
+	j, err := HashSettings([]byte(`{"user":"admin","pass":"hunter2"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := map[string]interface{}{}
+	if err := json.Unmarshal(j, &s); err != nil {
+		t.Fatal(err)
+	}
+	hash, _ := s["pass"].(string)
+	if !isHash(hash) || !verifyPassword(hash, "hunter2") {
+		t.Errorf("pass = %q, want a hash of hunter2", hash)
+	}
+	if s["user"] != "admin" {
+		t.Errorf("user = %v, want admin", s["user"])
+	}
+	//hashes are not hashed again
+	again, err := HashSettings(j)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(again) != string(j) {
+		t.Errorf("rehashed %s into %s", j, again)
+	}
+	if _, err := HashSettings([]byte(`{`)); err == nil {
+		t.Error("HashSettings accepted invalid JSON")
+	}
+}
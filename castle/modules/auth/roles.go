@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"net/http"
+	"path"
+	"strings"
+)
+
+//Role grants access to requests, each role
+//includes the access of the roles before it
+type Role string
+
+const (
+	Viewer   Role = "viewer"
+	Operator Role = "operator"
+	Admin    Role = "admin"
+)
+
+var roleRanks = map[Role]int{Viewer: 1, Operator: 2, Admin: 3}
+
+func (r Role) valid() bool {
+	return roleRanks[r] > 0
+}
+
+//allows reports whether r includes the access of required
+func (r Role) allows(required Role) bool {
+	return roleRanks[r] >= roleRanks[required]
+}
+
+//actions are reads which act on the world
+var actions = map[string]bool{
+	"/m/gpio/actuate": true,
+	"/m/radio/send":   true,
+}
+
+//public are reads served without authentication,
+//for load balancers and orchestrators to probe
+var public = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+//isPublic reports whether r may be made by anyone
+func isPublic(r *http.Request) bool {
+	return (r.Method == http.MethodGet || r.Method == http.MethodHead) && public[path.Clean(r.URL.Path)]
+}
+
+//requiredRole is the minimum role allowed to make r. Admins manage
+//the bot, its users and module settings. Operators may also actuate
+//pins, send radio codes, move the webcam and run rules. Viewers may
+//only read, seeing the dashboard and webcam.
+func requiredRole(r *http.Request) Role {
+	p := path.Clean(r.URL.Path)
+	//managing their own account
+	switch p {
+	case "/m/auth/me", "/m/auth/password", "/m/auth/totp":
+		return Viewer
+	}
+	if strings.HasPrefix(p, "/admin/") || strings.HasPrefix(p, "/m/auth/") {
+		return Admin
+	}
+	if actions[p] {
+		return Operator
+	}
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return Viewer
+	}
+	//module configuration: /m/<id>/<route>/...
+	if parts := strings.Split(p, "/"); len(parts) >= 4 && parts[1] == "m" {
+		switch parts[3] {
+		case "settings", "enabled", "logs":
+			return Admin
+		}
+		//rule definitions, though not running them
+		if parts[2] == "rules" && parts[3] == "rules" && !strings.HasSuffix(p, "/run") {
+			return Admin
+		}
+	}
+	return Operator
+}
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	sessionCookie = "castle_session"
+	sessionTTL    = 30 * 24 * time.Hour
+	//maxSessions bounds all sessions, and maxUserSessions the
+	//sessions of each user, so no user can end all others
+	maxSessions     = 1000
+	maxUserSessions = 20
+)
+
+type session struct {
+	user    string
+	expires time.Time
+}
+
+//startSession issues a session cookie for user, so later requests
+//are identified without checking credentials. Auth must be locked.
+func (a *Auth) startSession(w http.ResponseWriter, r *http.Request, user string) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return
+	}
+	now := time.Now()
+	//drop expired sessions, then the oldest of the
+	//user, or else the oldest, to make room
+	var oldest, oldestOfUser string
+	count := 0
+	for token, s := range a.sessions {
+		if now.After(s.expires) {
+			delete(a.sessions, token)
+			continue
+		}
+		if oldest == "" || s.expires.Before(a.sessions[oldest].expires) {
+			oldest = token
+		}
+		if s.user == user {
+			count++
+			if oldestOfUser == "" || s.expires.Before(a.sessions[oldestOfUser].expires) {
+				oldestOfUser = token
+			}
+		}
+	}
+	if count >= maxUserSessions {
+		delete(a.sessions, oldestOfUser)
+	} else if len(a.sessions) >= maxSessions {
+		delete(a.sessions, oldest)
+	}
+	token := hex.EncodeToString(b)
+	a.sessions[token] = &session{user: user, expires: now.Add(sessionTTL)}
+	http.SetCookie(w, &http.Cookie{
+		Name:     sessionCookie,
+		Value:    token,
+		Path:     "/",
+		Expires:  now.Add(sessionTTL),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+//browserLogin reports whether r is a browser page load, which
+//follows the login prompt. Other clients, such as scripts, present
+//credentials with every request and are not given sessions.
+func browserLogin(r *http.Request) bool {
+	if mode := r.Header.Get("Sec-Fetch-Mode"); mode != "" {
+		return mode == "navigate"
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+//sessionUser finds the user of the request's
+//session cookie. Auth must be locked.
+func (a *Auth) sessionUser(r *http.Request) (string, bool) {
+	c, err := r.Cookie(sessionCookie)
+	if err != nil {
+		return "", false
+	}
+	s, ok := a.sessions[c.Value]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(s.expires) {
+		delete(a.sessions, c.Value)
+		return "", false
+	}
+	return s.user, true
+}
+
+//endSessions ends all sessions of user. Auth must be locked.
+func (a *Auth) endSessions(user string) {
+	for token, s := range a.sessions {
+		if s.user == user {
+			delete(a.sessions, token)
+		}
+	}
+}
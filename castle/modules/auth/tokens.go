@@ -0,0 +1,287 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/util"
+	"goji.io/pat"
+)
+
+var tokensBucket = []byte("auth_tokens")
+
+//tokenPrefix marks bearer tokens, which are
+//castle_<id>_<secret>, so leaked tokens are recognisable
+const tokenPrefix = "castle_"
+
+//Token grants scripts access to the module routes matching
+//its scopes, with the access of its role, until it expires
+//or is revoked. Only a hash of its secret is stored.
+type Token struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Role      Role       `json:"role"`
+	Scopes    []string   `json:"scopes"`
+	Created   time.Time  `json:"created"`
+	CreatedBy string     `json:"createdBy,omitempty"`
+	Expires   *time.Time `json:"expires,omitempty"`
+	Hash      string     `json:"hash,omitempty"`
+}
+
+func (t *Token) expired() bool {
+	return t.Expires != nil && time.Now().After(*t.Expires)
+}
+
+//user identifies requests made with the token
+func (t *Token) user() string {
+	return "token:" + t.Name
+}
+
+//pathScopes grant access to routes outside of the modules
+var pathScopes = map[string]string{
+	"metrics": "/metrics",
+}
+
+//allows reports whether the scopes of t include the request
+//path p. Scopes are module routes, such as "radio/send", where
+//"gpio/*" includes all gpio routes and "*" includes everything,
+//or path scopes, such as "metrics" for Prometheus scrapes.
+func (t *Token) allows(p string) bool {
+	p = path.Clean(p)
+	route := strings.TrimPrefix(p, "/m/")
+	for _, scope := range t.Scopes {
+		if scope == "*" || pathScopes[scope] == p {
+			return true
+		}
+		if route == p {
+			//not a module route
+			continue
+		}
+		if prefix := strings.TrimSuffix(scope, "*"); prefix != scope {
+			if strings.HasPrefix(route+"/", prefix) {
+				return true
+			}
+		} else if route == scope {
+			return true
+		}
+	}
+	return false
+}
+
+func validScope(scope string) bool {
+	if scope == "*" {
+		return true
+	}
+	parts := strings.Split(scope, "/")
+	for i, part := range parts {
+		if part == "" || part == "." || part == ".." ||
+			(strings.Contains(part, "*") && (part != "*" || i == 0 || i != len(parts)-1)) {
+			return false
+		}
+	}
+	return true
+}
+
+func hashToken(secret string) string {
+	h := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(h[:])
+}
+
+//loadTokens reads all tokens from the database
+func (a *Auth) loadTokens() error {
+	tokens := map[string]*Token{}
+	err := a.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(tokensBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			t := &Token{}
+			if err := json.Unmarshal(v, t); err != nil {
+				a.log.Warn("invalid token", "id", string(k), "err", err)
+				return nil
+			}
+			tokens[t.ID] = t
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	a.tokens = tokens
+	return nil
+}
+
+func (a *Auth) saveToken(t *Token) error {
+	v, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(tokensBucket)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(t.ID), v)
+	})
+}
+
+func (a *Auth) deleteToken(id string) error {
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(tokensBucket)
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(id))
+	})
+}
+
+//bearerToken finds the unexpired token presented by r. Auth must be locked.
+func (a *Auth) bearerToken(r *http.Request) (*Token, bool) {
+	h := r.Header.Get("Authorization")
+	if !strings.HasPrefix(h, "Bearer ") {
+		return nil, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(h, "Bearer "+tokenPrefix), "_", 2)
+	if len(parts) != 2 {
+		return nil, false
+	}
+	t, ok := a.tokens[parts[0]]
+	if !ok || t.expired() {
+		return nil, false
+	}
+	if subtle.ConstantTimeCompare([]byte(hashToken(parts[1])), []byte(t.Hash)) != 1 {
+		return nil, false
+	}
+	return t, true
+}
+
+func (a *Auth) listTokens(w http.ResponseWriter, r *http.Request) {
+	a.mut.Lock()
+	list := []*Token{}
+	for _, t := range a.tokens {
+		c := *t
+		c.Hash = ""
+		list = append(list, &c)
+	}
+	a.mut.Unlock()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Created.Before(list[j].Created)
+	})
+	writeJSON(w, http.StatusOK, list)
+}
+
+//createToken issues a token, its secret
+//is only included in this response
+func (a *Auth) createToken(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		Name   string        `json:"name"`
+		Role   Role          `json:"role"`
+		Scopes []string      `json:"scopes"`
+		TTL    util.Duration `json:"ttl"`
+	}{Role: Operator}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid token: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validToken(req.Name, req.Role, req.Scopes, req.TTL.D()); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id := make([]byte, 8)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err := rand.Read(secret); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	t := &Token{
+		ID:        hex.EncodeToString(id),
+		Name:      req.Name,
+		Role:      req.Role,
+		Scopes:    req.Scopes,
+		Created:   time.Now(),
+		CreatedBy: util.RequestUser(r),
+		Hash:      hashToken(hex.EncodeToString(secret)),
+	}
+	if req.TTL > 0 {
+		expires := t.Created.Add(req.TTL.D())
+		t.Expires = &expires
+	}
+	a.mut.Lock()
+	for _, existing := range a.tokens {
+		if existing.Name == t.Name {
+			a.mut.Unlock()
+			http.Error(w, "Token name already in use", http.StatusConflict)
+			return
+		}
+	}
+	err := a.saveToken(t)
+	if err == nil {
+		a.tokens[t.ID] = t
+	}
+	a.mut.Unlock()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	a.log.Info("created token", "name", t.Name, "role", t.Role, "scopes", strings.Join(t.Scopes, ","), "by", t.CreatedBy)
+	c := *t
+	c.Hash = ""
+	writeJSON(w, http.StatusCreated, &struct {
+		Token
+		Secret string `json:"token"`
+	}{c, tokenPrefix + t.ID + "_" + hex.EncodeToString(secret)})
+}
+
+func validToken(name string, role Role, scopes []string, ttl time.Duration) error {
+	if name == "" {
+		return errors.New("Name is required")
+	}
+	if !role.valid() {
+		return errors.New("Role must be admin, operator or viewer")
+	}
+	if len(scopes) == 0 {
+		return errors.New("At least one scope is required")
+	}
+	for _, scope := range scopes {
+		if !validScope(scope) {
+			return errors.New("Invalid scope: " + scope)
+		}
+	}
+	if ttl < 0 {
+		return errors.New("TTL must not be negative")
+	}
+	return nil
+}
+
+func (a *Auth) revokeToken(w http.ResponseWriter, r *http.Request) {
+	id := pat.Param(r, "id")
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	t, ok := a.tokens[id]
+	if !ok {
+		http.Error(w, "Token not found", http.StatusNotFound)
+		return
+	}
+	if err := a.deleteToken(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	delete(a.tokens, id)
+	a.log.Info("revoked token", "name", t.Name, "by", util.RequestUser(r))
+	w.WriteHeader(http.StatusNoContent)
+}
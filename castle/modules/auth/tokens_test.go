@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTokenAllows(t *testing.T) {
+	for _, test := range []struct {
+		scopes []string
+		path   string
+		allows bool
+	}{
+		{[]string{"*"}, "/m/gpio/actuate", true},
+		{[]string{"*"}, "/admin/backup", true},
+		{[]string{"radio/send"}, "/m/radio/send", true},
+		{[]string{"radio/send"}, "/m/radio/send/", true},
+		{[]string{"radio/send"}, "/m/radio/codes", false},
+		{[]string{"radio/send"}, "/m/radio/send/x", false},
+		{[]string{"gpio/*"}, "/m/gpio/actuate", true},
+		{[]string{"gpio/*"}, "/m/gpio", true},
+		{[]string{"gpio/*"}, "/m/gpiox/actuate", false},
+		{[]string{"gpio/*"}, "/m/radio/send", false},
+		//paths are cleaned before matching
+		{[]string{"gpio/*"}, "/m/gpio/../auth/users", false},
+		{[]string{"radio/send"}, "/m//radio/./send", true},
+		//module scopes only match module routes
+		{[]string{"gpio/*"}, "/gpio/actuate", false},
+		{[]string{"metrics"}, "/metrics", true},
+		{[]string{"metrics"}, "/metricsx", false},
+		{[]string{"radio/send", "metrics"}, "/metrics", true},
+		{nil, "/m/radio/send", false},
+	} {
+		tok := &Token{Scopes: test.scopes}
+		if tok.allows(test.path) != test.allows {
+			t.Errorf("%v allows %s = %v, want %v", test.scopes, test.path, !test.allows, test.allows)
+		}
+	}
+}
+
+func TestValidScope(t *testing.T) {
+	for scope, valid := range map[string]bool{
+		"*":           true,
+		"metrics":     true,
+		"radio/send":  true,
+		"gpio/*":      true,
+		"rules/1/run": true,
+		"":            false,
+		"*/send":      false,
+		"gpio/*/x":    false,
+		"gpio/a*":     false,
+		"gpio//x":     false,
+		"gpio/":       false,
+		"/gpio":       false,
+		"gpio/../x":   false,
+		"./gpio":      false,
+	} {
+		if validScope(scope) != valid {
+			t.Errorf("validScope(%q) = %v, want %v", scope, !valid, valid)
+		}
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	h := hashToken("secret")
+	if len(h) != 64 {
+		t.Errorf("hash length %d, want 64", len(h))
+	}
+	if h != hashToken("secret") {
+		t.Error("hash not deterministic")
+	}
+	if h == hashToken("secret2") || h == "secret" {
+		t.Error("hash does not depend on the secret")
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	past, future := time.Now().Add(-time.Minute), time.Now().Add(time.Minute)
+	for _, test := range []struct {
+		expires *time.Time
+		expired bool
+	}{
+		{nil, false},
+		{&past, true},
+		{&future, false},
+	} {
+		if (&Token{Expires: test.expires}).expired() != test.expired {
+			t.Errorf("expires %v: expired = %v", test.expires, !test.expired)
+		}
+	}
+}
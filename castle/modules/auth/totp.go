@@ -0,0 +1,364 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/util"
+	"goji.io/pat"
+)
+
+var totpBucket = []byte("auth_totp")
+
+const (
+	//totpStep and totpDigits are the RFC 6238 defaults,
+	//which authenticator apps assume
+	totpStep   = 30
+	totpDigits = 6
+	//totpSkew is the steps either side of now accepted,
+	//allowing for clock drift between the bot and phone
+	totpSkew = 1
+	//recoveryCodes are issued on enrollment,
+	//each may be used once in place of a code
+	recoveryCodes = 10
+	//otpHeader may carry the code, otherwise it is
+	//appended to the password as <password>:<code>
+	otpHeader  = "X-OTP"
+	totpIssuer = "castlebot"
+)
+
+var b32 = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+var errCodeRequired = errors.New("Unauthorized: append your one-time code to your password, as <password>:<code>")
+
+//enrollment is the TOTP secret of a user, only enforced
+//once confirmed with a code. Recovery holds code hashes.
+type enrollment struct {
+	User      string   `json:"user"`
+	Secret    string   `json:"secret"`
+	Confirmed bool     `json:"confirmed"`
+	Recovery  []string `json:"recovery,omitempty"`
+	//LastStep prevents codes being replayed,
+	//including across restarts
+	LastStep int64 `json:"lastStep,omitempty"`
+}
+
+//totpCode computes the code of secret at step (RFC 4226 HOTP)
+func totpCode(secret []byte, step int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(msg)
+	h := mac.Sum(nil)
+	offset := h[len(h)-1] & 0xf
+	bin := binary.BigEndian.Uint32(h[offset:]) & 0x7fffffff
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, bin%mod)
+}
+
+//verifyCode checks code against the steps around now, returning
+//the matched step, which must be later than the last step used
+func (e *enrollment) verifyCode(code string, now time.Time) (int64, bool) {
+	secret, err := b32.DecodeString(e.Secret)
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+	step := now.Unix() / totpStep
+	for s := step - totpSkew; s <= step+totpSkew; s++ {
+		if s <= e.LastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, s)), []byte(code)) == 1 {
+			return s, true
+		}
+	}
+	return 0, false
+}
+
+//useRecovery consumes the recovery code matching code
+func (e *enrollment) useRecovery(code string) bool {
+	h := hashRecovery(code)
+	for i, r := range e.Recovery {
+		if subtle.ConstantTimeCompare([]byte(r), []byte(h)) == 1 {
+			e.Recovery = append(e.Recovery[:i:i], e.Recovery[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+//hashRecovery hashes a recovery code, ignoring
+//the case and dashes of how it was typed
+func hashRecovery(code string) string {
+	code = strings.ToUpper(strings.Replace(code, "-", "", -1))
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
+
+//uri is the otpauth provisioning URI of the enrollment,
+//which authenticator apps scan as a QR code
+func (e *enrollment) uri() string {
+	label := totpIssuer + ":" + e.User
+	q := url.Values{}
+	q.Set("secret", e.Secret)
+	q.Set("issuer", totpIssuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(totpDigits))
+	q.Set("period", fmt.Sprint(totpStep))
+	return "otpauth://totp/" + url.PathEscape(label) + "?" + q.Encode()
+}
+
+//splitCode separates the one-time code from the password of
+//an enrolled user, preferring the code of the OTP header
+func splitCode(r *http.Request, pass string) (string, string) {
+	if code := r.Header.Get(otpHeader); code != "" {
+		return pass, code
+	}
+	if i := strings.LastIndex(pass, ":"); i >= 0 {
+		return pass[:i], pass[i+1:]
+	}
+	return pass, ""
+}
+
+//checkCode verifies the code of an enrolled user, either a current
+//code or an unused recovery code. Auth must be locked.
+func (a *Auth) checkCode(user, code string) bool {
+	e, ok := a.totp[user]
+	if !ok || !e.Confirmed {
+		return true
+	}
+	if step, ok := e.verifyCode(code, time.Now()); ok {
+		e.LastStep = step
+		if err := a.saveEnrollment(e); err != nil {
+			a.log.Error("failed to store last code", "user", user, "err", err)
+		}
+		return true
+	}
+	if code != "" && e.useRecovery(code) {
+		if err := a.saveEnrollment(e); err != nil {
+			a.log.Error("failed to store recovery codes", "user", user, "err", err)
+		}
+		a.log.Warn("recovery code used", "user", user, "remaining", len(e.Recovery))
+		return true
+	}
+	return false
+}
+
+//enrolled reports whether user must provide a code. Auth must be locked.
+func (a *Auth) enrolled(user string) bool {
+	e, ok := a.totp[user]
+	return ok && e.Confirmed
+}
+
+func (a *Auth) loadEnrollments() error {
+	enrollments := map[string]*enrollment{}
+	err := a.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(totpBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			e := &enrollment{}
+			if err := json.Unmarshal(v, e); err != nil {
+				a.log.Warn("invalid totp enrollment", "user", string(k), "err", err)
+				return nil
+			}
+			enrollments[e.User] = e
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	a.totp = enrollments
+	return nil
+}
+
+func (a *Auth) saveEnrollment(e *enrollment) error {
+	v, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(totpBucket)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(e.User), v)
+	})
+}
+
+//removeEnrollment deletes the enrollment of user. Auth must be locked.
+func (a *Auth) removeEnrollment(user string) error {
+	if _, ok := a.totp[user]; !ok {
+		return nil
+	}
+	err := a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(totpBucket)
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(user))
+	})
+	if err != nil {
+		return err
+	}
+	delete(a.totp, user)
+	return nil
+}
+
+//enrollTOTP begins enrollment of the user making the request,
+//replacing any unconfirmed enrollment. The secret is returned
+//for the user to add to their authenticator app.
+func (a *Auth) enrollTOTP(w http.ResponseWriter, r *http.Request) {
+	user := util.RequestUser(r)
+	if user == "" {
+		http.Error(w, "No account to enroll, add one in the auth settings", http.StatusBadRequest)
+		return
+	}
+	if strings.HasPrefix(user, "token:") {
+		http.Error(w, "Tokens cannot enroll", http.StatusBadRequest)
+		return
+	}
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	e := &enrollment{User: user, Secret: b32.EncodeToString(secret)}
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	if a.enrolled(user) {
+		http.Error(w, "Already enrolled, disable two-factor authentication first", http.StatusConflict)
+		return
+	}
+	if err := a.saveEnrollment(e); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	a.totp[user] = e
+	writeJSON(w, http.StatusCreated, map[string]string{
+		"secret": e.Secret,
+		"uri":    e.uri(),
+	})
+}
+
+//confirmTOTP completes enrollment with a code from the authenticator
+//app, after which codes are required. Recovery codes are only
+//included in this response.
+func (a *Auth) confirmTOTP(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		Code string `json:"code"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Expecting valid JSON", http.StatusBadRequest)
+		return
+	}
+	user := util.RequestUser(r)
+	codes := make([]string, recoveryCodes)
+	hashes := make([]string, recoveryCodes)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		code := b32.EncodeToString(b)
+		codes[i] = code[:8] + "-" + code[8:]
+		hashes[i] = hashRecovery(code)
+	}
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	e, ok := a.totp[user]
+	if !ok || e.Confirmed {
+		http.Error(w, "No enrollment to confirm", http.StatusConflict)
+		return
+	}
+	step, ok := e.verifyCode(req.Code, time.Now())
+	if !ok {
+		http.Error(w, "Incorrect code", http.StatusBadRequest)
+		return
+	}
+	confirmed := *e
+	confirmed.Confirmed = true
+	confirmed.Recovery = hashes
+	confirmed.LastStep = step
+	if err := a.saveEnrollment(&confirmed); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	a.totp[user] = &confirmed
+	//log in again with codes
+	a.endSessions(user)
+	a.startSession(w, r, user)
+	a.log.Info("enabled two-factor authentication", "user", user)
+	writeJSON(w, http.StatusOK, map[string][]string{"recovery": codes})
+}
+
+//disableTOTP removes the enrollment of the user making the
+//request, who must provide a current or recovery code
+func (a *Auth) disableTOTP(w http.ResponseWriter, r *http.Request) {
+	req := struct {
+		Code string `json:"code"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Expecting valid JSON", http.StatusBadRequest)
+		return
+	}
+	user := util.RequestUser(r)
+	ip := remoteIP(r)
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	if l, ok := a.checkLocked(ip, user).(*lockedOut); ok {
+		w.Header().Set("Retry-After", l.retryAfter())
+		http.Error(w, l.Error(), http.StatusTooManyRequests)
+		return
+	}
+	if a.enrolled(user) && !a.checkCode(user, req.Code) {
+		a.login(user, false, r)
+		a.failed(ip, user)
+		http.Error(w, "Incorrect code", http.StatusForbidden)
+		return
+	}
+	if err := a.removeEnrollment(user); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	a.log.Info("disabled two-factor authentication", "user", user)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//resetTOTP lets admins remove the enrollment of
+//a user who has lost their authenticator app
+func (a *Auth) resetTOTP(w http.ResponseWriter, r *http.Request) {
+	name := pat.Param(r, "name")
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	if _, ok := a.totp[name]; !ok {
+		http.Error(w, "User is not enrolled", http.StatusNotFound)
+		return
+	}
+	if err := a.removeEnrollment(name); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	a.log.Info("reset two-factor authentication", "user", name, "by", util.RequestUser(r))
+	w.WriteHeader(http.StatusNoContent)
+}
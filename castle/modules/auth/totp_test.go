@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+//rfcSecret is the secret of the RFC 4226 test vectors
+var rfcSecret = []byte("12345678901234567890")
+
+func TestTOTPCode(t *testing.T) {
+	//RFC 4226 appendix D
+	for step, code := range []string{
+		"755224", "287082", "359152", "969429", "338314",
+		"254676", "287922", "162583", "399871", "520489",
+	} {
+		if c := totpCode(rfcSecret, int64(step)); c != code {
+			t.Errorf("totpCode(%d) = %s, want %s", step, c, code)
+		}
+	}
+}
+
+func TestVerifyCode(t *testing.T) {
+	now := time.Unix(1000*totpStep+10, 0)
+	for _, test := range []struct {
+		name     string
+		code     string
+		lastStep int64
+		step     int64
+		ok       bool
+	}{
+		{"current", totpCode(rfcSecret, 1000), 0, 1000, true},
+		{"previous", totpCode(rfcSecret, 999), 0, 999, true},
+		{"next", totpCode(rfcSecret, 1001), 0, 1001, true},
+		{"too old", totpCode(rfcSecret, 998), 0, 0, false},
+		{"too new", totpCode(rfcSecret, 1002), 0, 0, false},
+		{"replayed", totpCode(rfcSecret, 1000), 1000, 0, false},
+		{"before last", totpCode(rfcSecret, 999), 1000, 0, false},
+		{"after last", totpCode(rfcSecret, 1001), 1000, 1001, true},
+		{"short", totpCode(rfcSecret, 1000)[1:], 0, 0, false},
+		{"empty", "", 0, 0, false},
+		{"wrong", "000000", 0, 0, false},
+	} {
+		e := &enrollment{Secret: b32.EncodeToString(rfcSecret), LastStep: test.lastStep}
+		step, ok := e.verifyCode(test.code, now)
+		if ok != test.ok || step != test.step {
+			t.Errorf("%s: verifyCode = %d %v, want %d %v", test.name, step, ok, test.step, test.ok)
+		}
+	}
+}
+
+func TestVerifyCodeInvalidSecret(t *testing.T) {
+	e := &enrollment{Secret: "not base32!"}
+	if _, ok := e.verifyCode("123456", time.Now()); ok {
+		t.Error("verifyCode accepted a code of an invalid secret")
+	}
+}
+
+func TestUseRecovery(t *testing.T) {
+	e := &enrollment{Recovery: []string{
+		hashRecovery("AAAA-BBBB"),
+		hashRecovery("CCCC-DDDD"),
+	}}
+	for _, test := range []struct {
+		code string
+		ok   bool
+		left int
+	}{
+		{"EEEE-FFFF", false, 2},
+		//case and dashes are ignored
+		{"aaaabbbb", true, 1},
+		//each code is used once
+		{"AAAA-BBBB", false, 1},
+		{"cccc-dddd", true, 0},
+	} {
+		if ok := e.useRecovery(test.code); ok != test.ok {
+			t.Errorf("useRecovery(%s) = %v, want %v", test.code, ok, test.ok)
+		}
+		if len(e.Recovery) != test.left {
+			t.Errorf("useRecovery(%s) left %d codes, want %d", test.code, len(e.Recovery), test.left)
+		}
+	}
+}
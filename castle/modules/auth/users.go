@@ -0,0 +1,201 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/util"
+	"goji.io/pat"
+)
+
+var usersBucket = []byte("auth_users")
+
+//User is an account in addition to the admin
+//account of the auth settings, Pass is a hash
+type User struct {
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+	Pass string `json:"pass,omitempty"`
+}
+
+//loadUsers reads all users from the database
+func (a *Auth) loadUsers() error {
+	users := map[string]*User{}
+	err := a.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(usersBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			u := &User{}
+			if err := json.Unmarshal(v, u); err != nil {
+				a.log.Warn("invalid user", "name", string(k), "err", err)
+				return nil
+			}
+			users[u.Name] = u
+			return nil
+		})
+	})
+	if err != nil {
+		return err
+	}
+	//users stored before passwords were hashed
+	for _, u := range users {
+		if isHash(u.Pass) {
+			continue
+		}
+		hash, err := hashPassword(u.Pass)
+		if err != nil {
+			return err
+		}
+		u.Pass = hash
+		if err := a.saveUser(u); err != nil {
+			return err
+		}
+		a.log.Info("hashed plaintext password", "user", u.Name)
+	}
+	a.users = users
+	return nil
+}
+
+func (a *Auth) saveUser(u *User) error {
+	v, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(usersBucket)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(u.Name), v)
+	})
+}
+
+func (a *Auth) deleteUser(name string) error {
+	return a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(usersBucket)
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(name))
+	})
+}
+
+func (a *Auth) listUsers(w http.ResponseWriter, r *http.Request) {
+	a.mut.Lock()
+	list := []*User{}
+	for _, u := range a.users {
+		list = append(list, &User{Name: u.Name, Role: u.Role})
+	}
+	a.mut.Unlock()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	writeJSON(w, http.StatusOK, list)
+}
+
+//putUser creates or updates a user, the
+//password may be omitted when updating
+func (a *Auth) putUser(w http.ResponseWriter, r *http.Request) {
+	name := pat.Param(r, "name")
+	u := &User{}
+	if err := json.NewDecoder(r.Body).Decode(u); err != nil {
+		http.Error(w, "Invalid user: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	u.Name = name
+	hash, err := hashPassword(u.Pass)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	u.Pass = hash
+	status, err := a.setUser(u)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+	a.log.Info("updated user", "name", name, "role", u.Role, "by", util.RequestUser(r))
+	writeJSON(w, status, &User{Name: u.Name, Role: u.Role})
+}
+
+func (a *Auth) setUser(u *User) (int, error) {
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	if u.Name == "" {
+		return http.StatusBadRequest, errors.New("Name is required")
+	}
+	if u.Name == a.settings.User {
+		return http.StatusConflict, errors.New("Name is used by the admin account in the auth settings")
+	}
+	if !u.Role.valid() {
+		return http.StatusBadRequest, errors.New("Role must be admin, operator or viewer")
+	}
+	existing, ok := a.users[u.Name]
+	if u.Pass == "" {
+		if !ok {
+			return http.StatusBadRequest, errors.New("Password is required")
+		}
+		u.Pass = existing.Pass
+	}
+	if err := a.saveUser(u); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	a.users[u.Name] = u
+	if ok && u.Pass != existing.Pass {
+		a.endSessions(u.Name)
+	}
+	if ok {
+		return http.StatusOK, nil
+	}
+	return http.StatusCreated, nil
+}
+
+func (a *Auth) removeUser(w http.ResponseWriter, r *http.Request) {
+	name := pat.Param(r, "name")
+	a.mut.Lock()
+	defer a.mut.Unlock()
+	if _, ok := a.users[name]; !ok {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+	if err := a.deleteUser(name); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	delete(a.users, name)
+	a.endSessions(name)
+	if err := a.removeEnrollment(name); err != nil {
+		a.log.Error("failed to remove totp enrollment", "user", name, "err", err)
+	}
+	a.log.Info("deleted user", "name", name, "by", util.RequestUser(r))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//me describes the user making the request
+func (a *Auth) me(w http.ResponseWriter, r *http.Request) {
+	name := util.RequestUser(r)
+	a.mut.Lock()
+	role, _ := a.roleOf(name)
+	totp := a.enrolled(name)
+	a.mut.Unlock()
+	writeJSON(w, http.StatusOK, &struct {
+		User
+		TOTP bool `json:"totp"`
+	}{User{Name: name, Role: role}, totp})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
@@ -0,0 +1,267 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+const (
+	filePrefix = "castle-"
+	fileSuffix = ".db"
+	timeFormat = "20060102T150405Z"
+)
+
+func New(db *bolt.DB) *Backup {
+	b := &Backup{db: db}
+	b.timer = time.NewTimer(time.Duration(0))
+	b.timer.Stop()
+	return b
+}
+
+type Backup struct {
+	db       *bolt.DB
+	updates  chan interface{}
+	log      *logs.Logger
+	worker   util.Worker
+	timer    *time.Timer
+	settings settings
+	status   struct {
+		BackupAt time.Time `json:"backupAt"`
+		File     string    `json:"file,omitempty"`
+		Size     int64     `json:"size"`
+		Error    string    `json:"error,omitempty"`
+	}
+}
+
+type settings struct {
+	Dir      string        `json:"dir" help:"directory to write backups into" default:"backups"`
+	Interval util.Duration `json:"interval" help:"time between backups" default:"24h"`
+	Keep     int           `json:"keep" help:"number of backups to keep" default:"7"`
+}
+
+func (b *Backup) ID() string {
+	return "backup"
+}
+
+func (b *Backup) EnabledByDefault() bool {
+	return false
+}
+
+func (b *Backup) SetLogger(l *logs.Logger) {
+	b.log = l
+}
+
+func (b *Backup) Start() error {
+	b.worker.Start(b.check)
+	return nil
+}
+
+func (b *Backup) Stop() error {
+	b.worker.Stop()
+	return nil
+}
+
+func (b *Backup) check(done <-chan struct{}) {
+	for {
+		//wait until the newest backup is <interval> old,
+		//short-circuited by Set()
+		b.timer.Reset(b.untilNext())
+		select {
+		case <-b.timer.C:
+		case <-done:
+			b.timer.Stop()
+			return
+		}
+		if b.untilNext() > 0 {
+			continue
+		}
+		if err := b.backup(); err != nil {
+			b.log.Error("backup failed", "err", err)
+			b.status.Error = err.Error()
+			b.push()
+			//retry after an hour at most
+			b.timer.Reset(minDuration(time.Hour, b.settings.Interval.D()))
+			select {
+			case <-b.timer.C:
+			case <-done:
+				b.timer.Stop()
+				return
+			}
+		}
+	}
+}
+
+//untilNext is the time until the next backup is due
+func (b *Backup) untilNext() time.Duration {
+	files, _ := b.files()
+	if len(files) == 0 {
+		return 0
+	}
+	newest := files[len(files)-1]
+	t, err := time.Parse(timeFormat, strings.TrimSuffix(strings.TrimPrefix(newest, filePrefix), fileSuffix))
+	if err != nil {
+		return 0
+	}
+	next := b.settings.Interval.D() - time.Since(t)
+	if next < 0 {
+		next = 0
+	}
+	return next
+}
+
+//files lists the backup files in dir, oldest first
+func (b *Backup) files() ([]string, error) {
+	infos, err := ioutil.ReadDir(b.settings.Dir)
+	if err != nil {
+		return nil, err
+	}
+	files := []string{}
+	for _, info := range infos {
+		name := info.Name()
+		if !info.IsDir() && strings.HasPrefix(name, filePrefix) && strings.HasSuffix(name, fileSuffix) {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+//backup writes a consistent snapshot of the database into dir,
+//then removes all but the newest <keep> backups
+func (b *Backup) backup() error {
+	dir := b.settings.Dir
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	name := filePrefix + now.Format(timeFormat) + fileSuffix
+	path := filepath.Join(dir, name)
+	//write via a temp file so partial backups are never left behind
+	var size int64
+	if err := b.db.View(func(tx *bolt.Tx) error {
+		f, err := os.OpenFile(path+".tmp", os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		size, err = tx.WriteTo(f)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}); err != nil {
+		os.Remove(path + ".tmp")
+		return err
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return err
+	}
+	b.log.Info("wrote backup", "file", path, "size", size)
+	b.status.BackupAt = now
+	b.status.File = name
+	b.status.Size = size
+	b.status.Error = ""
+	b.push()
+	//retention
+	files, err := b.files()
+	if err != nil {
+		return err
+	}
+	for len(files) > b.settings.Keep {
+		if err := os.Remove(filepath.Join(dir, files[0])); err != nil {
+			return err
+		}
+		b.log.Info("removed backup", "file", files[0])
+		files = files[1:]
+	}
+	return nil
+}
+
+//Download serves a consistent snapshot of the database
+func (b *Backup) Download(w http.ResponseWriter, r *http.Request) {
+	err := b.db.View(func(tx *bolt.Tx) error {
+		name := filePrefix + time.Now().UTC().Format(timeFormat) + fileSuffix
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, name))
+		w.Header().Set("Content-Length", strconv.FormatInt(tx.Size(), 10))
+		_, err := tx.WriteTo(w)
+		return err
+	})
+	if err != nil {
+		b.log.Error("download failed", "err", err)
+	}
+}
+
+func (b *Backup) Status(updates chan interface{}) {
+	b.updates = updates
+	b.push()
+}
+
+func (b *Backup) push() {
+	if b.updates != nil {
+		b.updates <- &b.status
+	}
+}
+
+func (b *Backup) Get() interface{} {
+	return &b.settings
+}
+
+func (b *Backup) Set(j json.RawMessage) error {
+	//rejected settings leave the current ones untouched
+	settings, err := b.parse(j)
+	if err != nil {
+		return err
+	}
+	b.settings = settings
+	b.timer.Reset(0)
+	return nil
+}
+
+//Validate checks j without applying it
+func (b *Backup) Validate(j json.RawMessage) error {
+	_, err := b.parse(j)
+	return err
+}
+
+//parse decodes j over a copy of the current settings
+func (b *Backup) parse(j json.RawMessage) (settings, error) {
+	s := b.settings
+	if j != nil {
+		if err := json.Unmarshal(j, &s); err != nil {
+			return s, err
+		}
+	}
+	if s.Dir == "" {
+		s.Dir = "backups"
+	}
+	if s.Interval <= 0 {
+		s.Interval = util.Duration(24 * time.Hour)
+	} else if s.Interval.D() < time.Minute {
+		return s, errors.New("Interval must be at least 1m")
+	}
+	if s.Keep <= 0 {
+		s.Keep = 7
+	}
+	return s, nil
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
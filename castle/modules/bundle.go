@@ -0,0 +1,290 @@
+package modules
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//Bundle contains the settings of all modules (and optionally
+//other buckets) so that they may be restored on another bot.
+//Bundles are signed with HMAC-SHA256 using a key chosen by
+//the client, sent in the X-Bundle-Key header.
+type Bundle struct {
+	Version   int                          `json:"version"`
+	Created   time.Time                    `json:"created"`
+	Modules   map[string]json.RawMessage   `json:"modules"`
+	Enabled   map[string]bool              `json:"enabled,omitempty"`
+	Versions  map[string]int               `json:"versions,omitempty"`
+	Buckets   map[string]map[string][]byte `json:"buckets,omitempty"`
+	Signature string                       `json:"signature"`
+}
+
+//BundleResult describes the outcome of importing a bundle
+type BundleResult struct {
+	DryRun bool `json:"dryRun"`
+	//Aborted imports were not applied, since a module rejected them
+	Aborted  bool              `json:"aborted,omitempty"`
+	Accepted []string          `json:"accepted"`
+	Rejected map[string]string `json:"rejected"`
+	Buckets  map[string]int    `json:"buckets,omitempty"`
+}
+
+const (
+	bundleVersion   = 1
+	bundleKeyHeader = "X-Bundle-Key"
+)
+
+var errBundleSignature = errors.New("invalid bundle signature")
+
+//sign computes the signature of the bundle,
+//excluding any existing signature
+func (b Bundle) sign(key string) (string, error) {
+	b.Signature = ""
+	j, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(j)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+//ExportHandler serves a signed bundle of all module settings,
+//including the given buckets when requested with ?buckets=true
+func (s *Modules) ExportHandler(buckets ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(bundleKeyHeader)
+		if key == "" {
+			http.Error(w, "Missing "+bundleKeyHeader+" header", http.StatusBadRequest)
+			return
+		}
+		b := Bundle{
+			Version:  bundleVersion,
+			Created:  time.Now(),
+			Modules:  map[string]json.RawMessage{},
+			Enabled:  map[string]bool{},
+			Versions: map[string]int{},
+		}
+		for _, module := range s.order {
+			if _, ok := module.raw.(Toggleable); ok {
+				b.Enabled[module.ID] = module.isEnabled()
+			}
+			if module.settable == nil {
+				continue
+			}
+			j, err := json.Marshal(module.settable.Get())
+			if err != nil {
+				http.Error(w, "Settings contain invalid JSON", http.StatusInternalServerError)
+				return
+			}
+			b.Modules[module.ID] = j
+			b.Versions[module.ID] = settingsVersion(module.raw)
+		}
+		if r.URL.Query().Get("buckets") == "true" {
+			b.Buckets = map[string]map[string][]byte{}
+			for _, name := range buckets {
+				b.Buckets[name] = s.dbdump(name)
+			}
+		}
+		sig, err := b.sign(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		b.Signature = sig
+		j, err := json.MarshalIndent(&b, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="castle-settings.json"`)
+		w.Write(j)
+	}
+}
+
+//ImportHandler verifies and applies a bundle. With ?dryrun=true
+//the bundle is only validated, reporting which modules would reject it.
+func (s *Modules) ImportHandler(buckets ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(bundleKeyHeader)
+		if key == "" {
+			http.Error(w, "Missing "+bundleKeyHeader+" header", http.StatusBadRequest)
+			return
+		}
+		b := Bundle{}
+		if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
+			http.Error(w, "Expecting valid JSON", http.StatusBadRequest)
+			return
+		}
+		if b.Version != bundleVersion {
+			http.Error(w, "Unsupported bundle version", http.StatusBadRequest)
+			return
+		}
+		if sig, err := b.sign(key); err != nil || !hmac.Equal([]byte(sig), []byte(b.Signature)) {
+			http.Error(w, errBundleSignature.Error(), http.StatusForbidden)
+			return
+		}
+		dryRun := r.URL.Query().Get("dryrun") == "true"
+		result := s.importBundle(&b, dryRun, util.RequestUser(r), buckets)
+		j, _ := json.MarshalIndent(result, "", "  ")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(j)
+	}
+}
+
+func (s *Modules) importBundle(b *Bundle, dryRun bool, user string, buckets []string) *BundleResult {
+	result := &BundleResult{
+		DryRun:   dryRun,
+		Accepted: []string{},
+		Rejected: map[string]string{},
+	}
+	for id := range b.Modules {
+		if m, ok := s.modules[id]; !ok || m.settable == nil {
+			result.Rejected[id] = "unknown module"
+		}
+	}
+	//validate all modules before applying any
+	prepared := map[string]json.RawMessage{}
+	invalid := false
+	for _, module := range s.order {
+		j, ok := b.Modules[module.ID]
+		if !ok || module.settable == nil {
+			continue
+		}
+		j, err := prepareSettings(module, j, b.Versions[module.ID])
+		if err != nil {
+			result.Rejected[module.ID] = err.Error()
+			invalid = true
+			continue
+		}
+		prepared[module.ID] = j
+	}
+	if invalid && !dryRun {
+		result.Aborted = true
+		s.log.Warn("import aborted", "rejected", len(result.Rejected))
+		return result
+	}
+	for _, module := range s.order {
+		j, ok := prepared[module.ID]
+		if !ok {
+			continue
+		}
+		if !dryRun {
+			module.mut.Lock()
+			err := s.applySettings(module, j, user)
+			module.mut.Unlock()
+			if err != nil {
+				result.Rejected[module.ID] = err.Error()
+				continue
+			}
+			if enabled, ok := b.Enabled[module.ID]; ok {
+				if err := s.setEnabled(module, enabled); err != nil {
+					module.logger.Error("failed to import enabled", "err", err)
+				}
+			}
+		}
+		result.Accepted = append(result.Accepted, module.ID)
+	}
+	//restore permitted buckets
+	if len(b.Buckets) > 0 {
+		result.Buckets = map[string]int{}
+		for _, name := range buckets {
+			contents, ok := b.Buckets[name]
+			if !ok {
+				continue
+			}
+			if !dryRun {
+				if err := s.dbrestore(name, contents); err != nil {
+					result.Rejected["bucket:"+name] = err.Error()
+					continue
+				}
+			}
+			result.Buckets[name] = len(contents)
+		}
+	}
+	if !dryRun {
+		s.log.Info("imported settings", "accepted", len(result.Accepted), "rejected", len(result.Rejected))
+		s.state.Push()
+	}
+	return result
+}
+
+//prepareSettings restores the secrets of the bundled settings j of
+//module, migrates them from version and validates the result
+func prepareSettings(module *Module, j json.RawMessage, version int) (json.RawMessage, error) {
+	module.mut.Lock()
+	defer module.mut.Unlock()
+	//restore secrets before migrating, since
+	//placeholders are not values to migrate
+	j, err := unredactSettings(module, j)
+	if err != nil {
+		return nil, err
+	}
+	//bundles from older bots may need migrating
+	if j, err = migrate(module.raw, j, version); err != nil {
+		return nil, err
+	}
+	if err := validateSettings(module, j); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+//validateSettings checks j using the module's Validator, which
+//performs the checks of its Set, falling back to decoding into a
+//copy of its settings for modules whose Set checks nothing more.
+//module.mut must be held.
+func validateSettings(module *Module, j json.RawMessage) error {
+	if validator, ok := module.raw.(Validator); ok {
+		return validator.Validate(j)
+	}
+	t := reflect.TypeOf(module.settable.Get())
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return json.Unmarshal(j, reflect.New(t).Interface())
+}
+
+//dbdump copies the contents of bucket name
+func (s *Modules) dbdump(name string) map[string][]byte {
+	contents := map[string][]byte{}
+	s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(name))
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			if v != nil {
+				contents[string(k)] = append([]byte{}, v...)
+			}
+			return nil
+		})
+	})
+	return contents
+}
+
+//dbrestore writes contents into bucket name
+func (s *Modules) dbrestore(name string, contents map[string][]byte) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(name))
+		if err != nil {
+			return err
+		}
+		for k, v := range contents {
+			if err := b.Put([]byte(k), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
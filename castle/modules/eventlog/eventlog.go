@@ -0,0 +1,295 @@
+package eventlog
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+var bucketName = []byte("events")
+
+//maxBatch bounds the events stored in one transaction
+const maxBatch = 256
+
+func New(db *bolt.DB) *EventLog {
+	l := &EventLog{db: db}
+	l.timer = time.NewTimer(time.Duration(0))
+	l.timer.Stop()
+	return l
+}
+
+//EventLog stores all published events
+//until they are older than the retention
+type EventLog struct {
+	db       *bolt.DB
+	bus      *events.Bus
+	log      *logs.Logger
+	updates  chan interface{}
+	worker   util.Worker
+	timer    *time.Timer
+	settings settings
+	status   struct {
+		Events   int        `json:"events"`
+		Dropped  int        `json:"dropped,omitempty"`
+		OldestAt *time.Time `json:"oldestAt,omitempty"`
+	}
+}
+
+type settings struct {
+	Retention util.Duration `json:"retention" help:"time events are kept for" default:"720h"`
+	Max       int           `json:"max" help:"number of events kept" default:"100000"`
+	Exclude   []events.Type `json:"exclude" help:"event types which are not stored"`
+}
+
+func (l *EventLog) ID() string {
+	return "eventlog"
+}
+
+func (l *EventLog) EnabledByDefault() bool {
+	return true
+}
+
+func (l *EventLog) SetLogger(logger *logs.Logger) {
+	l.log = logger
+}
+
+func (l *EventLog) SetBus(bus *events.Bus) {
+	l.bus = bus
+}
+
+func (l *EventLog) Start() error {
+	l.worker.Start(l.check)
+	return nil
+}
+
+func (l *EventLog) Stop() error {
+	l.worker.Stop()
+	return nil
+}
+
+//check stores events as they are published,
+//pruning old events every hour
+func (l *EventLog) check(done <-chan struct{}) {
+	sub := l.bus.Subscribe("")
+	defer sub.Close()
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	l.prune()
+	dropped := uint64(0)
+	for {
+		select {
+		case e, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			//store all waiting events at once
+			batch, open := drain(e, sub.C)
+			l.store(batch)
+			//events published faster than they are stored are missed
+			if n := sub.Dropped(); n > dropped {
+				l.log.Warn("events dropped", "count", n-dropped)
+				l.status.Dropped += int(n - dropped)
+				dropped = n
+				l.push()
+			}
+			if !open {
+				return
+			}
+		case <-ticker.C:
+			l.prune()
+		case <-l.timer.C:
+			//settings changed
+			l.prune()
+		case <-done:
+			l.timer.Stop()
+			return
+		}
+	}
+}
+
+func (l *EventLog) excluded(t events.Type) bool {
+	for _, x := range l.settings.Exclude {
+		if x == t {
+			return true
+		}
+	}
+	return false
+}
+
+//drain collects e along with the events waiting on c,
+//reporting whether c remains open
+func drain(e events.Event, c <-chan events.Event) ([]events.Event, bool) {
+	batch := []events.Event{e}
+	for len(batch) < maxBatch {
+		select {
+		case e, ok := <-c:
+			if !ok {
+				return batch, false
+			}
+			batch = append(batch, e)
+		default:
+			return batch, true
+		}
+	}
+	return batch, true
+}
+
+//store appends the events of batch which are not excluded
+func (l *EventLog) store(batch []events.Event) {
+	kept := []events.Event{}
+	for _, e := range batch {
+		if !l.excluded(e.Type) {
+			kept = append(kept, e)
+		}
+	}
+	if len(kept) == 0 {
+		return
+	}
+	if err := l.append(kept); err != nil {
+		l.log.Error("failed to store", "count", len(kept), "err", err)
+		return
+	}
+	l.status.Events += len(kept)
+	l.push()
+}
+
+//append stores es in a single transaction,
+//each keyed by its time then sequence
+func (l *EventLog) append(es []events.Event) error {
+	return l.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucketName)
+		if err != nil {
+			return err
+		}
+		for i := range es {
+			v, err := json.Marshal(&es[i])
+			if err != nil {
+				return err
+			}
+			seq, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			if err := b.Put(eventKey(es[i].Time, seq), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+//prune deletes events beyond the retention and max
+func (l *EventLog) prune() {
+	cutoff := eventKey(time.Now().Add(-l.settings.Retention.D()), 0)
+	max := l.settings.Max
+	n := 0
+	err := l.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		if b == nil {
+			return nil
+		}
+		//collect before deleting, deleting
+		//while iterating skips entries
+		old := [][]byte{}
+		c := b.Cursor()
+		for k, _ := c.Last(); k != nil; k, _ = c.Prev() {
+			if n >= max || bytes.Compare(k, cutoff) < 0 {
+				old = append(old, append([]byte{}, k...))
+			} else {
+				n++
+			}
+		}
+		for _, k := range old {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		if len(old) > 0 {
+			l.log.Info("pruned events", "count", len(old))
+		}
+		l.status.OldestAt = nil
+		if k, _ := b.Cursor().First(); k != nil {
+			t := keyTime(k)
+			l.status.OldestAt = &t
+		}
+		return nil
+	})
+	if err != nil {
+		l.log.Error("failed to prune", "err", err)
+	}
+	l.status.Events = n
+	l.push()
+}
+
+func eventKey(t time.Time, seq uint64) []byte {
+	k := make([]byte, 16)
+	binary.BigEndian.PutUint64(k, uint64(t.UnixNano()))
+	binary.BigEndian.PutUint64(k[8:], seq)
+	return k
+}
+
+func keyTime(k []byte) time.Time {
+	return time.Unix(0, int64(binary.BigEndian.Uint64(k)))
+}
+
+func (l *EventLog) Status(updates chan interface{}) {
+	l.updates = updates
+	l.push()
+}
+
+func (l *EventLog) push() {
+	if l.updates != nil {
+		l.updates <- &l.status
+	}
+}
+
+func (l *EventLog) Get() interface{} {
+	return &l.settings
+}
+
+func (l *EventLog) Set(j json.RawMessage) error {
+	//rejected settings leave the current ones untouched
+	settings, err := l.parse(j)
+	if err != nil {
+		return err
+	}
+	l.settings = settings
+	l.timer.Reset(0)
+	return nil
+}
+
+//Validate checks j without applying it
+func (l *EventLog) Validate(j json.RawMessage) error {
+	_, err := l.parse(j)
+	return err
+}
+
+//parse decodes j over a copy of the current settings
+func (l *EventLog) parse(j json.RawMessage) (settings, error) {
+	s := l.settings
+	if j != nil {
+		if err := json.Unmarshal(j, &s); err != nil {
+			return s, err
+		}
+	}
+	if s.Retention <= 0 {
+		s.Retention = util.Duration(30 * 24 * time.Hour)
+	} else if s.Retention.D() < time.Hour {
+		return s, errors.New("Retention must be at least 1h")
+	}
+	if s.Max <= 0 {
+		s.Max = 100000
+	}
+	//machine stats are too frequent to keep by default
+	if s.Exclude == nil {
+		s.Exclude = []events.Type{events.MachineStats}
+	}
+	return s, nil
+}
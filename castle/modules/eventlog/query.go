@@ -0,0 +1,168 @@
+package eventlog
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/events"
+)
+
+const (
+	defaultLimit = 100
+	maxLimit     = 1000
+)
+
+//storedEvent is an event as read back from the store
+type storedEvent struct {
+	Module string          `json:"module"`
+	Type   events.Type     `json:"type"`
+	Time   time.Time       `json:"time"`
+	Data   json.RawMessage `json:"data,omitempty"`
+}
+
+//Page is a single page of query results, newest first.
+//When more events match, Next is the cursor of the next page.
+type Page struct {
+	Events []*storedEvent `json:"events"`
+	Next   string         `json:"next,omitempty"`
+}
+
+//query filters the stored events
+type query struct {
+	module string
+	types  map[events.Type]bool
+	since  time.Time
+	until  time.Time
+	cursor []byte
+	limit  int
+}
+
+//Query serves stored events, newest first, filtered with ?module=<id>,
+//any number of ?type=<type>, ?since= and ?until= (RFC3339 times or
+//durations ago, such as 12h), paginated with ?limit= and ?cursor=
+func (l *EventLog) Query(w http.ResponseWriter, r *http.Request) {
+	q, err := parseQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, err := l.query(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	b, err := json.MarshalIndent(page, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(b)
+}
+
+func parseQuery(r *http.Request) (*query, error) {
+	v := r.URL.Query()
+	q := &query{module: v.Get("module"), limit: defaultLimit}
+	if types := v["type"]; len(types) > 0 {
+		q.types = map[events.Type]bool{}
+		for _, t := range types {
+			q.types[events.Type(t)] = true
+		}
+	}
+	var err error
+	if q.since, err = parseTime(v.Get("since")); err != nil {
+		return nil, fmt.Errorf("invalid since: %s", err)
+	}
+	if q.until, err = parseTime(v.Get("until")); err != nil {
+		return nil, fmt.Errorf("invalid until: %s", err)
+	}
+	if s := v.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid limit: %s", s)
+		}
+		if n > maxLimit {
+			n = maxLimit
+		}
+		q.limit = n
+	}
+	if s := v.Get("cursor"); s != "" {
+		c, err := hex.DecodeString(s)
+		if err != nil || len(c) != 16 {
+			return nil, fmt.Errorf("invalid cursor: %s", s)
+		}
+		q.cursor = c
+	}
+	return q, nil
+}
+
+//parseTime accepts RFC3339 times and durations before now
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+func (l *EventLog) query(q *query) (*Page, error) {
+	page := &Page{Events: []*storedEvent{}}
+	err := l.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		//position at the newest event before
+		//the cursor, or until, or the end
+		var k, v []byte
+		start := q.cursor
+		if start == nil && !q.until.IsZero() {
+			start = eventKey(q.until.Add(1), 0)
+		}
+		if start == nil {
+			k, v = c.Last()
+		} else if k, v = c.Seek(start); k == nil {
+			k, v = c.Last()
+		} else {
+			k, v = c.Prev()
+		}
+		var since []byte
+		if !q.since.IsZero() {
+			since = eventKey(q.since, 0)
+		}
+		var last []byte
+		for ; k != nil; k, v = c.Prev() {
+			if since != nil && bytes.Compare(k, since) < 0 {
+				break
+			}
+			e := &storedEvent{}
+			if err := json.Unmarshal(v, e); err != nil {
+				continue
+			}
+			if q.module != "" && e.Module != q.module {
+				continue
+			}
+			if q.types != nil && !q.types[e.Type] {
+				continue
+			}
+			if len(page.Events) == q.limit {
+				//more remain, continue from the last returned
+				page.Next = hex.EncodeToString(last)
+				break
+			}
+			page.Events = append(page.Events, e)
+			last = k
+		}
+		return nil
+	})
+	return page, err
+}
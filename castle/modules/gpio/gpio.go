@@ -2,40 +2,168 @@ package gpio
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/metrics"
+	"github.com/jpillora/castlebot/castle/util"
 	"github.com/jpillora/go433"
 	goji "goji.io"
 	"goji.io/pat"
 )
 
 func New() *GPIO {
-	return &GPIO{Enabled: false}
+	return &GPIO{}
 }
 
 type GPIO struct {
-	Enabled bool `json:"enabled"`
+	mut      sync.Mutex
+	stop     chan struct{}
+	active   sync.WaitGroup
+	bus      *events.Bus
+	log      *logs.Logger
+	actuated *metrics.Counter
+	worker   util.Worker
+	settings settings
+}
+
+type settings struct {
+	Inputs []int         `json:"inputs" help:"pins watched for input edges"`
+	Poll   util.Duration `json:"poll" help:"time between input reads" default:"50ms"`
 }
 
 func (h *GPIO) ID() string {
 	return "gpio"
 }
 
+func (h *GPIO) EnabledByDefault() bool {
+	return false
+}
+
+func (h *GPIO) Start() error {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	if h.stop == nil {
+		h.stop = make(chan struct{})
+	}
+	h.worker.Start(h.watch)
+	return nil
+}
+
+//Stop releases all actuated pins early
+func (h *GPIO) Stop() error {
+	h.mut.Lock()
+	if h.stop != nil {
+		close(h.stop)
+		h.stop = nil
+	}
+	h.mut.Unlock()
+	h.active.Wait()
+	h.worker.Stop()
+	return nil
+}
+
+//watch polls the input pins, publishing their edges
+func (h *GPIO) watch(done <-chan struct{}) {
+	inputs := h.settings.Inputs
+	pins := []*inputPin{}
+	values := []bool{}
+	for _, n := range inputs {
+		pin, err := openPinIn(n)
+		if err != nil {
+			h.log.Error("failed to open input", "pin", n, "err", err)
+			continue
+		}
+		v, _ := pin.read()
+		pins = append(pins, pin)
+		values = append(values, v)
+	}
+	if len(pins) == 0 {
+		return
+	}
+	ticker := time.NewTicker(h.settings.Poll.D())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+		for i, pin := range pins {
+			v, err := pin.read()
+			if err != nil || v == values[i] {
+				continue
+			}
+			values[i] = v
+			h.bus.Publish(events.Event{
+				Module: h.ID(),
+				Type:   events.PinEdge,
+				Data:   events.Edge{Pin: pin.n, High: v},
+			})
+		}
+	}
+}
+
 func (h *GPIO) Get() interface{} {
-	return h
+	return &h.settings
 }
 
 func (h *GPIO) Set(j json.RawMessage) error {
+	//rejected settings leave the current ones untouched
+	settings, err := h.parse(j)
+	if err != nil {
+		return err
+	}
+	h.settings = settings
+	//rewatch with the new inputs
+	if h.worker.Running() {
+		h.worker.Stop()
+		h.worker.Start(h.watch)
+	}
+	return nil
+}
+
+//Validate checks j without applying it
+func (h *GPIO) Validate(j json.RawMessage) error {
+	_, err := h.parse(j)
+	return err
+}
+
+//parse decodes j over a copy of the current settings
+func (h *GPIO) parse(j json.RawMessage) (settings, error) {
+	s := h.settings
 	if j != nil {
-		if err := json.Unmarshal(j, &h); err != nil {
-			return err
+		if err := json.Unmarshal(j, &s); err != nil {
+			return s, err
 		}
 	}
-	return nil
+	if s.Inputs == nil {
+		s.Inputs = []int{}
+	}
+	if s.Poll <= 0 {
+		s.Poll = util.Duration(50 * time.Millisecond)
+	} else if s.Poll.D() < time.Millisecond {
+		return s, errors.New("Poll must be at least 1ms")
+	}
+	return s, nil
+}
+
+func (h *GPIO) SetLogger(l *logs.Logger) {
+	h.log = l
+}
+
+func (h *GPIO) SetBus(bus *events.Bus) {
+	h.bus = bus
+}
+
+func (h *GPIO) SetMetrics(reg *metrics.Registry) {
+	h.actuated = reg.Counter("castle_gpio_actuations_total", "Pin actuations, by pin", "pin")
 }
 
 func (g *GPIO) RegisterRoutes(mux *goji.Mux) {
@@ -43,10 +171,6 @@ func (g *GPIO) RegisterRoutes(mux *goji.Mux) {
 }
 
 func (h *GPIO) actuate(w http.ResponseWriter, r *http.Request) {
-	if !h.Enabled {
-		http.Error(w, "GPIO not active", 500)
-		return
-	}
 	q := r.URL.Query()
 	var err error
 	p := 17
@@ -65,19 +189,43 @@ func (h *GPIO) actuate(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
-	pin, err := go433.OpenPinOut(p)
-	if err != nil {
+	if err := h.Pulse(p, d); err != nil {
 		http.Error(w, err.Error(), 400)
 		return
 	}
-	//actuate
-	go func() {
-		pin.Write(true)
-		time.Sleep(d)
-		pin.Write(false)
-		log.Printf("activated pin %d for %s\n", p, d)
-	}()
 	//done
 	w.WriteHeader(200)
 	fmt.Fprintf(w, "activating pin %d for %s\n", p, d)
 }
+
+//Pulse drives pin p high for d, or until stopped
+func (h *GPIO) Pulse(p int, d time.Duration) error {
+	h.mut.Lock()
+	defer h.mut.Unlock()
+	if h.stop == nil {
+		return errors.New("GPIO not active")
+	}
+	pin, err := go433.OpenPinOut(p)
+	if err != nil {
+		return err
+	}
+	//actuate
+	h.actuated.Inc(strconv.Itoa(p))
+	h.bus.Publish(events.Event{
+		Module: h.ID(),
+		Type:   events.PinActuated,
+		Data:   events.Pin{Pin: p, Duration: util.Duration(d)},
+	})
+	h.active.Add(1)
+	go func(stop chan struct{}) {
+		defer h.active.Done()
+		pin.Write(true)
+		select {
+		case <-time.After(d):
+		case <-stop:
+		}
+		pin.Write(false)
+		h.log.Info("activated pin", "pin", p, "duration", d)
+	}(h.stop)
+	return nil
+}
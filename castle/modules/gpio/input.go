@@ -0,0 +1,38 @@
+package gpio
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const sysfsGPIO = "/sys/class/gpio"
+
+//inputPin reads a pin configured as an input via sysfs
+type inputPin struct {
+	n     int
+	value string
+}
+
+func openPinIn(n int) (*inputPin, error) {
+	dir := filepath.Join(sysfsGPIO, "gpio"+strconv.Itoa(n))
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := ioutil.WriteFile(filepath.Join(sysfsGPIO, "export"), []byte(strconv.Itoa(n)), 0200); err != nil {
+			return nil, err
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "direction"), []byte("in"), 0200); err != nil {
+		return nil, err
+	}
+	return &inputPin{n: n, value: filepath.Join(dir, "value")}, nil
+}
+
+func (p *inputPin) read() (bool, error) {
+	b, err := ioutil.ReadFile(p.value)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(b)) == "1", nil
+}
@@ -0,0 +1,162 @@
+package modules
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+//HealthChecker modules report whether they are working,
+//they are only checked while enabled
+type HealthChecker interface {
+	Health() error
+}
+
+const (
+	healthOK          = "ok"
+	healthFailing     = "failing"
+	healthDisabled    = "disabled"
+	healthUnavailable = "unavailable"
+	//dbCheckInterval limits database write checks, since
+	//health checks may be requested by anyone
+	dbCheckInterval = 30 * time.Second
+)
+
+//Health is the result of a single check
+type Health struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+//HealthReport is the result of checking the
+//database and each registered module
+type HealthReport struct {
+	Status  string             `json:"status"`
+	Ready   bool               `json:"ready"`
+	DB      *Health            `json:"db"`
+	Modules map[string]*Health `json:"modules"`
+}
+
+func newHealth(err error) *Health {
+	if err != nil {
+		return &Health{Status: healthFailing, Error: err.Error()}
+	}
+	return &Health{Status: healthOK}
+}
+
+//setReady marks whether the modules have been started
+func (s *Modules) setReady(ready bool) {
+	v := int32(0)
+	if ready {
+		v = 1
+	}
+	atomic.StoreInt32(&s.ready, v)
+}
+
+func (s *Modules) isReady() bool {
+	return atomic.LoadInt32(&s.ready) == 1
+}
+
+//checkHealth checks the database is writable
+//and all enabled modules are healthy
+func (s *Modules) checkHealth() *HealthReport {
+	report := &HealthReport{
+		Status:  healthOK,
+		Ready:   s.isReady(),
+		DB:      newHealth(s.checkDB()),
+		Modules: map[string]*Health{},
+	}
+	if report.DB.Status != healthOK {
+		report.Status = healthFailing
+	}
+	for _, module := range s.order {
+		if !module.isEnabled() {
+			report.Modules[module.ID] = &Health{Status: healthDisabled}
+			continue
+		}
+		var err error
+		if checker, ok := module.raw.(HealthChecker); ok {
+			err = checker.Health()
+		}
+		h := newHealth(err)
+		if h.Status != healthOK {
+			report.Status = healthFailing
+		}
+		report.Modules[module.ID] = h
+	}
+	return report
+}
+
+//checkDB commits an empty write transaction, which fails
+//when the database file cannot be written, at most once
+//per check interval
+func (s *Modules) checkDB() error {
+	c := &s.dbCheck
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	if time.Since(c.checked) < dbCheckInterval {
+		return c.err
+	}
+	c.err = s.db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	c.checked = time.Now()
+	return c.err
+}
+
+//HealthHandler responds 503 when any check fails. Only
+//the status is included, since anyone may probe it.
+func (s *Modules) HealthHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeStatus(w, s.checkHealth().Status)
+	}
+}
+
+//ReadyHandler additionally responds 503 until all modules
+//have started, and again once they begin stopping
+func (s *Modules) ReadyHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := s.checkHealth().Status
+		if !s.isReady() && status == healthOK {
+			status = healthUnavailable
+		}
+		writeStatus(w, status)
+	}
+}
+
+//ReportHandler reports the health of the database and each
+//module, responding 503 when any check fails
+func (s *Modules) ReportHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := s.checkHealth()
+		b, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if report.Status != healthOK {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		w.Write(b)
+	}
+}
+
+func writeStatus(w http.ResponseWriter, status string) {
+	b, err := json.Marshal(&struct {
+		Status string `json:"status"`
+	}{status})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if status != healthOK {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(b)
+}
@@ -0,0 +1,273 @@
+package modules
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/util"
+	"goji.io/pat"
+)
+
+//Revision is a single stored change to a module's settings
+type Revision struct {
+	Rev      uint64          `json:"rev"`
+	Time     time.Time       `json:"time"`
+	User     string          `json:"user,omitempty"`
+	Version  int             `json:"version"`
+	Diff     json.RawMessage `json:"diff"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+//maxRevisions is the number of revisions kept per module
+const maxRevisions = 50
+
+var historyBucketName = []byte("settings_history")
+
+var errRevisionNotFound = errors.New("revision not found")
+
+//dbsetRevision stores the settings (at version) of module id,
+//recording the change as a new revision in the same transaction
+func (s *Modules) dbsetRevision(id string, version int, contents []byte, user string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(bucketName)
+		if err != nil {
+			return err
+		}
+		prev := b.Get([]byte(id))
+		if bytes.Equal(prev, contents) {
+			//no change
+			return b.Put([]byte(versionKey(id)), []byte(strconv.Itoa(version)))
+		}
+		prevVersion, _ := strconv.Atoi(string(b.Get([]byte(versionKey(id)))))
+		h, err := tx.CreateBucketIfNotExists(historyBucketName)
+		if err != nil {
+			return err
+		}
+		mh, err := h.CreateBucketIfNotExists([]byte(id))
+		if err != nil {
+			return err
+		}
+		//first revision? record the existing settings as a baseline
+		if k, _ := mh.Cursor().First(); k == nil && len(prev) > 0 {
+			if err := putRevision(mh, nil, prev, prevVersion, ""); err != nil {
+				return err
+			}
+		}
+		if err := putRevision(mh, prev, contents, version, user); err != nil {
+			return err
+		}
+		if err := trimRevisions(mh, maxRevisions); err != nil {
+			return err
+		}
+		if err := b.Put([]byte(versionKey(id)), []byte(strconv.Itoa(version))); err != nil {
+			return err
+		}
+		return b.Put([]byte(id), contents)
+	})
+}
+
+func putRevision(mh *bolt.Bucket, prev, contents []byte, version int, user string) error {
+	diff, err := util.MergeDiff(prev, contents)
+	if err != nil {
+		return err
+	}
+	rev, err := mh.NextSequence()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(&Revision{
+		Rev:      rev,
+		Time:     time.Now(),
+		User:     user,
+		Version:  version,
+		Diff:     diff,
+		Settings: json.RawMessage(contents),
+	})
+	if err != nil {
+		return err
+	}
+	return mh.Put(revKey(rev), b)
+}
+
+//trimRevisions deletes the oldest revisions beyond max
+func trimRevisions(mh *bolt.Bucket, max int) error {
+	n := 0
+	old := [][]byte{}
+	c := mh.Cursor()
+	for k, _ := c.Last(); k != nil; k, _ = c.Prev() {
+		n++
+		if n > max {
+			old = append(old, append([]byte{}, k...))
+		}
+	}
+	for _, k := range old {
+		if err := mh.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//revisions returns the stored revisions of module id, newest first
+func (s *Modules) revisions(id string) ([]*Revision, error) {
+	revs := []*Revision{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		h := tx.Bucket(historyBucketName)
+		if h == nil {
+			return nil
+		}
+		mh := h.Bucket([]byte(id))
+		if mh == nil {
+			return nil
+		}
+		c := mh.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			r := &Revision{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return err
+			}
+			revs = append(revs, r)
+		}
+		return nil
+	})
+	return revs, err
+}
+
+func (s *Modules) revision(id string, rev uint64) (*Revision, error) {
+	r := &Revision{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		h := tx.Bucket(historyBucketName)
+		if h == nil {
+			return errRevisionNotFound
+		}
+		mh := h.Bucket([]byte(id))
+		if mh == nil {
+			return errRevisionNotFound
+		}
+		v := mh.Get(revKey(rev))
+		if v == nil {
+			return errRevisionNotFound
+		}
+		return json.Unmarshal(v, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+//scrubRevisions redacts the secrets of the revisions of module
+//older than version, such as plaintext passwords stored before
+//a migration hashed them. Rollbacks to them keep current secrets.
+func (s *Modules) scrubRevisions(module *Module, version int) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		h := tx.Bucket(historyBucketName)
+		if h == nil {
+			return nil
+		}
+		mh := h.Bucket([]byte(module.ID))
+		if mh == nil {
+			return nil
+		}
+		scrubbed := map[string][]byte{}
+		err := mh.ForEach(func(k, v []byte) error {
+			r := &Revision{}
+			if err := json.Unmarshal(v, r); err != nil {
+				return err
+			}
+			if r.Version >= version {
+				return nil
+			}
+			r.Diff = module.schema.redactJSON(r.Diff)
+			r.Settings = module.schema.redactJSON(r.Settings)
+			b, err := json.Marshal(r)
+			if err != nil {
+				return err
+			}
+			scrubbed[string(k)] = b
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		//buckets may not be modified while iterating
+		for k, v := range scrubbed {
+			if err := mh.Put([]byte(k), v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func revKey(rev uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, rev)
+	return k
+}
+
+func (s *Modules) getHistoryHandler(module *Module) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		revs, err := s.revisions(module.ID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		for _, rev := range revs {
+			rev.Diff = module.schema.redactJSON(rev.Diff)
+			rev.Settings = module.schema.redactJSON(rev.Settings)
+		}
+		b, err := json.MarshalIndent(revs, "", "  ")
+		if err != nil {
+			http.Error(w, "History contains invalid JSON", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	}
+}
+
+//rollbackHandler re-applies the settings of a previous
+//revision, which is itself recorded as a new revision
+func (s *Modules) rollbackHandler(module *Module) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rev, err := strconv.ParseUint(pat.Param(r, "rev"), 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid revision", http.StatusBadRequest)
+			return
+		}
+		revision, err := s.revision(module.ID, rev)
+		if err == errRevisionNotFound {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		} else if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		module.mut.Lock()
+		defer module.mut.Unlock()
+		//restore secrets before migrating, so placeholders
+		//of scrubbed revisions are not mistaken for values
+		settings, err := unredactSettings(module, revision.Settings)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		settings, err = migrate(module.raw, settings, revision.Version)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := s.applySettings(module, settings, util.RequestUser(r)); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		module.logger.Info("rolled back settings", "revision", rev)
+	}
+}
@@ -0,0 +1,65 @@
+package modules
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jpillora/castlebot/castle/logs"
+)
+
+//Loggable modules write through their own logger, which
+//prefixes messages with the module ID and keeps recent
+//messages for viewing at /m/<id>/logs
+type Loggable interface {
+	SetLogger(*logs.Logger)
+}
+
+//loadLevel finds the stored log level of module id
+func (s *Modules) loadLevel(id string) logs.Level {
+	b := s.dbget(levelKey(id))
+	if len(b) == 0 {
+		return logs.Info
+	}
+	level, err := logs.ParseLevel(string(b))
+	if err != nil {
+		s.log.Warn("invalid log level", "module", id, "err", err)
+	}
+	return level
+}
+
+//setLevel changes the log level of module, then records it
+func (s *Modules) setLevel(module *Module, level logs.Level) error {
+	module.mut.Lock()
+	defer module.mut.Unlock()
+	module.logger.SetLevel(level)
+	module.LogLevel = level
+	return s.dbset(levelKey(module.ID), []byte(level.String()))
+}
+
+func (s *Modules) updateLevelHandler(module *Module) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var level logs.Level
+		if err := json.NewDecoder(r.Body).Decode(&level); err != nil {
+			http.Error(w, "Expecting a level: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := s.setLevel(module, level); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		module.logger.Info("updated log level", "level", level)
+		s.state.Push()
+	}
+}
+
+//CloseLogs ends all log streams
+func (s *Modules) CloseLogs() {
+	for _, module := range s.order {
+		module.logger.Close()
+	}
+}
+
+//levelKey is the settings bucket key of a module's log level
+func levelKey(id string) string {
+	return id + ".loglevel"
+}
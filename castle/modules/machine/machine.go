@@ -5,6 +5,9 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/metrics"
+	"github.com/jpillora/castlebot/castle/util"
 	"github.com/shirou/gopsutil/cpu"
 	"github.com/shirou/gopsutil/disk"
 	"github.com/shirou/gopsutil/mem"
@@ -14,19 +17,23 @@ func New() *Machine {
 	m := &Machine{}
 	m.timer = time.NewTimer(time.Duration(0))
 	m.timer.Stop()
-	m.settings.Interval = 5 * time.Second
-	go m.check()
+	m.settings.Interval = util.Duration(5 * time.Second)
 	return m
 }
 
 type Machine struct {
 	updates  chan interface{}
+	bus      *events.Bus
+	worker   util.Worker
 	timer    *time.Timer
 	settings struct {
-		Interval time.Duration
+		Interval util.Duration `json:"interval" help:"time between stats collection" default:"5s"`
 	}
 	lastCPUStat cpu.TimesStat
-	status      struct {
+	gauges      struct {
+		cpu, diskUsed, diskTotal, memoryUsed, memoryTotal, goMemory, goRoutines *metrics.Gauge
+	}
+	status struct {
 		CPU         float64 `json:"cpu"`
 		DiskUsed    int64   `json:"diskUsed"`
 		DiskTotal   int64   `json:"diskTotal"`
@@ -41,13 +48,32 @@ func (a *Machine) ID() string {
 	return "machine"
 }
 
-func (m *Machine) check() {
+func (m *Machine) EnabledByDefault() bool {
+	return true
+}
+
+func (m *Machine) Start() error {
+	m.worker.Start(m.check)
+	return nil
+}
+
+func (m *Machine) Stop() error {
+	m.worker.Stop()
+	return nil
+}
+
+func (m *Machine) check(done <-chan struct{}) {
 	first := true
 	for {
 		//wait here for <interval>
 		//short-circuited by Set()
-		m.timer.Reset(m.settings.Interval)
-		<-m.timer.C
+		m.timer.Reset(m.settings.Interval.D())
+		select {
+		case <-m.timer.C:
+		case <-done:
+			m.timer.Stop()
+			return
+		}
 		//load
 		m.loadStats(first)
 		first = false
@@ -94,7 +120,39 @@ func (m *Machine) loadStats(first bool) {
 	//count current number of goroutines
 	m.status.GoRoutines = runtime.NumGoroutine()
 	//done
+	m.gauges.cpu.Set(m.status.CPU)
+	m.gauges.diskUsed.Set(float64(m.status.DiskUsed))
+	m.gauges.diskTotal.Set(float64(m.status.DiskTotal))
+	m.gauges.memoryUsed.Set(float64(m.status.MemoryUsed))
+	m.gauges.memoryTotal.Set(float64(m.status.MemoryTotal))
+	m.gauges.goMemory.Set(float64(m.status.GoMemory))
+	m.gauges.goRoutines.Set(float64(m.status.GoRoutines))
 	m.push()
+	m.bus.Publish(events.Event{
+		Module: m.ID(),
+		Type:   events.MachineStats,
+		Data: events.Stats{
+			CPU:         m.status.CPU,
+			DiskUsed:    m.status.DiskUsed,
+			DiskTotal:   m.status.DiskTotal,
+			MemoryUsed:  m.status.MemoryUsed,
+			MemoryTotal: m.status.MemoryTotal,
+		},
+	})
+}
+
+func (m *Machine) SetBus(bus *events.Bus) {
+	m.bus = bus
+}
+
+func (m *Machine) SetMetrics(reg *metrics.Registry) {
+	m.gauges.cpu = reg.Gauge("castle_machine_cpu_percent", "CPU usage")
+	m.gauges.diskUsed = reg.Gauge("castle_machine_disk_used_bytes", "Disk space used")
+	m.gauges.diskTotal = reg.Gauge("castle_machine_disk_total_bytes", "Disk space")
+	m.gauges.memoryUsed = reg.Gauge("castle_machine_memory_used_bytes", "Memory used")
+	m.gauges.memoryTotal = reg.Gauge("castle_machine_memory_total_bytes", "Memory")
+	m.gauges.goMemory = reg.Gauge("castle_go_memory_bytes", "Bytes allocated by the Go runtime")
+	m.gauges.goRoutines = reg.Gauge("castle_go_goroutines", "Number of goroutines")
 }
 
 func (m *Machine) Status(updates chan interface{}) {
@@ -112,12 +170,29 @@ func (m *Machine) Get() interface{} {
 	return &m.settings
 }
 
+func (m *Machine) Migrations() []func(map[string]interface{}) error {
+	return []func(map[string]interface{}) error{
+		//v1: interval as a duration string, previously nanoseconds
+		func(s map[string]interface{}) error {
+			if v, ok := s["Interval"]; ok {
+				delete(s, "Interval")
+				s["interval"] = v
+			}
+			util.MigrateNanoseconds(s, "interval")
+			return nil
+		},
+	}
+}
+
 func (m *Machine) Set(j json.RawMessage) error {
 	if j != nil {
 		if err := json.Unmarshal(j, &m.settings); err != nil {
 			return err
 		}
 	}
+	if m.settings.Interval <= 0 {
+		m.settings.Interval = util.Duration(5 * time.Second)
+	}
 	//do stuff
 	m.timer.Reset(0)
 	return nil
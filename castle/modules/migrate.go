@@ -0,0 +1,83 @@
+package modules
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//Migratable modules version their settings. Migrations()[i]
+//upgrades stored settings from version i to version i+1,
+//so the current version is len(Migrations()). Settings
+//stored before versioning was introduced are version 0.
+type Migratable interface {
+	Migrations() []func(settings map[string]interface{}) error
+}
+
+//settingsVersion is the current settings version of module
+func settingsVersion(raw Identified) int {
+	if migratable, ok := raw.(Migratable); ok {
+		return len(migratable.Migrations())
+	}
+	return 0
+}
+
+//storedVersion is the version of the stored settings of module id
+func (s *Modules) storedVersion(id string) int {
+	v, _ := strconv.Atoi(string(s.dbget(versionKey(id))))
+	return v
+}
+
+//migrate upgrades the settings b from version to the current version
+func migrate(raw Identified, b []byte, version int) ([]byte, error) {
+	migratable, ok := raw.(Migratable)
+	if !ok {
+		return b, nil
+	}
+	migrations := migratable.Migrations()
+	if version >= len(migrations) {
+		return b, nil
+	}
+	settings := map[string]interface{}{}
+	if err := json.Unmarshal(b, &settings); err != nil {
+		return nil, err
+	}
+	for v := version; v < len(migrations); v++ {
+		if err := migrations[v](settings); err != nil {
+			return nil, fmt.Errorf("migration to version %d: %s", v+1, err)
+		}
+	}
+	return json.Marshal(settings)
+}
+
+//migrateStored upgrades the stored settings of module, writing
+//the upgraded settings back as a new revision. Older revisions
+//are scrubbed of secrets, which migrations may have changed.
+func (s *Modules) migrateStored(module *Module, b []byte) []byte {
+	from := s.storedVersion(module.ID)
+	to := settingsVersion(module.raw)
+	if from >= to {
+		return b
+	}
+	upgraded, err := migrate(module.raw, b, from)
+	if err != nil {
+		module.logger.Error("failed to migrate settings", "err", err)
+		return b
+	}
+	diff, _ := util.MergeDiff(b, upgraded)
+	module.logger.Info("migrated settings", "from", from, "to", to, "diff", diff)
+	if err := s.dbsetRevision(module.ID, to, upgraded, "migration"); err != nil {
+		module.logger.Error("failed to store settings", "err", err)
+	}
+	if err := s.scrubRevisions(module, to); err != nil {
+		module.logger.Error("failed to scrub settings history", "err", err)
+	}
+	return upgraded
+}
+
+//versionKey is the settings bucket key of a module's settings version
+func versionKey(id string) string {
+	return id + ".version"
+}
@@ -2,8 +2,12 @@ package modules
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	goji "goji.io"
@@ -11,6 +15,11 @@ import (
 	"goji.io/pat"
 
 	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/metrics"
+	"github.com/jpillora/castlebot/castle/series"
+	"github.com/jpillora/castlebot/castle/util"
 	"github.com/jpillora/velox"
 )
 
@@ -27,30 +36,114 @@ type Settable interface {
 	Set(json.RawMessage) error
 }
 
+//Validator modules check settings without applying them, using
+//the checks of their Set. Modules whose Set checks nothing beyond
+//decoding need not be Validators.
+type Validator interface {
+	Validate(json.RawMessage) error
+}
+
+//SettingsUpdater modules change their own settings using
+//update, which applies and stores them as if sent by user
+type SettingsUpdater interface {
+	SetUpdater(update func(settings json.RawMessage, user string) error)
+}
+
+//Publisher modules emit events, the bus is
+//provided on registration before Start
+type Publisher interface {
+	SetBus(*events.Bus)
+}
+
+//Instrumented modules register their metrics,
+//served from /metrics, before Start
+type Instrumented interface {
+	SetMetrics(*metrics.Registry)
+}
+
 type Routable interface {
 	RegisterRoutes(*goji.Mux)
 }
 
+//Startable modules begin their background work in Start,
+//which is called once all modules have been registered
+type Startable interface {
+	Start() error
+}
+
+//Stoppable modules release their goroutines, timers
+//and listeners in Stop, which must block until done
+type Stoppable interface {
+	Stop() error
+}
+
+//Toggleable modules may be enabled and disabled at runtime.
+//While disabled, a module is stopped, its routes respond
+//with 404 and its status is hidden. Modules which are not
+//Toggleable are always enabled.
+type Toggleable interface {
+	//EnabledByDefault is used until an enabled state is stored
+	EnabledByDefault() bool
+}
+
 type Module struct {
 	ID       string `json:"id"`
+	raw      Identified
 	settable Settable
+	schema   *Schema
+	logger   *logs.Logger
+	mut      sync.Mutex
+	//enabled mirrors Enabled for readers not holding mut
+	enabled  int32
+	Enabled  bool        `json:"enabled"`
+	LogLevel logs.Level  `json:"logLevel"`
 	Settings interface{} `json:"settings,omitempty"`
 	Status   interface{} `json:"status,omitempty"`
 }
 
+//isEnabled may be called without holding mut
+func (m *Module) isEnabled() bool {
+	return atomic.LoadInt32(&m.enabled) == 1
+}
+
+//markEnabled records the enabled state, mut must be held
+func (m *Module) markEnabled(enabled bool) {
+	v := int32(0)
+	if enabled {
+		v = 1
+	}
+	m.Enabled = enabled
+	atomic.StoreInt32(&m.enabled, v)
+}
+
 type Modules struct {
 	db      *bolt.DB
 	router  *goji.Mux
 	modules map[string]*Module
+	order   []*Module
 	state   velox.Pusher
+	bus     *events.Bus
+	metrics *metrics.Registry
+	log     *logs.Logger
+	series  *series.Store
+	ready   int32
+	dbCheck struct {
+		mut     sync.Mutex
+		checked time.Time
+		err     error
+	}
 }
 
-func New(db *bolt.DB, router *goji.Mux, state velox.Pusher) *Modules {
+func New(db *bolt.DB, router *goji.Mux, state velox.Pusher, bus *events.Bus, reg *metrics.Registry) *Modules {
 	s := &Modules{}
 	s.db = db
 	s.router = router
 	s.modules = map[string]*Module{}
 	s.state = state
+	s.bus = bus
+	s.metrics = reg
+	s.log = logs.New("modules")
+	s.series = series.New(db)
 	return s
 }
 
@@ -65,31 +158,72 @@ func (s *Modules) Register(rawModule Identified) {
 	}
 	module := &Module{
 		ID:       id,
+		raw:      rawModule,
 		Settings: nil,
 		Status:   nil,
 	}
+	module.markEnabled(true)
 	//register subrouter
 	subrouter := goji.SubMux()
 	s.router.Handle(pat.New("/m/"+id+"/*"), subrouter)
+	//pass module logger
+	module.logger = logs.New(id)
+	module.LogLevel = s.loadLevel(id)
+	module.logger.SetLevel(module.LogLevel)
+	if loggable, ok := rawModule.(Loggable); ok {
+		loggable.SetLogger(module.logger)
+	}
+	subrouter.Handle(pat.Get("/logs"), module.logger)
+	subrouter.Handle(pat.Put("/logs/level"), s.updateLevelHandler(module))
+	//load enabled state
+	if toggleable, ok := rawModule.(Toggleable); ok {
+		module.markEnabled(s.loadEnabled(id, toggleable))
+		subrouter.Handle(pat.Put("/enabled"), s.updateEnabledHandler(module))
+	}
+	//pass event bus and metrics before settings,
+	//which modules may use as they are applied
+	if publisher, ok := rawModule.(Publisher); ok {
+		publisher.SetBus(s.bus)
+	}
+	if instrumented, ok := rawModule.(Instrumented); ok {
+		instrumented.SetMetrics(s.metrics)
+	}
 	//load module settings
 	if settable, ok := rawModule.(Settable); ok {
+		//schema first, migrations scrub secrets
+		module.schema = newSchema(id, settable.Get())
 		//load from db?
 		b := s.dbget(id)
 		if len(b) > 0 {
-			log.Printf("loaded existing config for: %s", id)
+			module.logger.Info("loaded existing config")
+			b = s.migrateStored(module, b)
 			settable.Set(json.RawMessage(b))
 		} else {
 			settable.Set(nil) //signal use defaults
+			//settings stored from now on are the current version
+			s.dbset(versionKey(id), []byte(strconv.Itoa(settingsVersion(rawModule))))
 		}
 		//initial value
-		module.Settings = settable.Get()
 		module.settable = settable
+		module.Settings = redactedSettings(module)
 		//rest api
 		subrouter.Handle(pat.Get("/settings"), s.getSettingsHandler(module))
 		subrouter.Handle(pat.Put("/settings"), s.updateSettingsHandler(module))
+		subrouter.Handle(pat.Patch("/settings"), s.patchSettingsHandler(module))
+		subrouter.Handle(pat.Get("/settings/schema"), s.getSchemaHandler(module))
+		subrouter.Handle(pat.Get("/settings/history"), s.getHistoryHandler(module))
+		subrouter.Handle(pat.Post("/settings/rollback/:rev"), s.rollbackHandler(module))
+		if updater, ok := rawModule.(SettingsUpdater); ok {
+			updater.SetUpdater(func(j json.RawMessage, user string) error {
+				module.mut.Lock()
+				defer module.mut.Unlock()
+				return s.applySettings(module, j, user)
+			})
+		}
 	}
 	//pass module status update channel
 	if statuser, ok := rawModule.(Statusable); ok {
+		subrouter.Handle(pat.Get("/history"), s.series.Handler(id))
 		updates := make(chan interface{})
 		go s.watchUpdates(module, updates)
 		go func() {
@@ -97,17 +231,143 @@ func (s *Modules) Register(rawModule Identified) {
 			statuser.Status(updates)
 		}()
 	}
-	//register module routers
+	//register module routers, only reachable while enabled
 	if routable, ok := rawModule.(Routable); ok {
-		routable.RegisterRoutes(subrouter)
+		modrouter := goji.SubMux()
+		modrouter.Use(s.enabledMiddleware(module))
+		routable.RegisterRoutes(modrouter)
+		subrouter.Handle(pat.New("/*"), modrouter)
 	}
 	//register
 	s.modules[id] = module
+	s.order = append(s.order, module)
+}
+
+//Start starts all enabled Startable modules in registration order,
+//and the recording of their status history
+func (s *Modules) Start() error {
+	s.series.Start()
+	for _, module := range s.order {
+		if !module.isEnabled() {
+			continue
+		}
+		if startable, ok := module.raw.(Startable); ok {
+			if err := startable.Start(); err != nil {
+				return fmt.Errorf("start %s: %s", module.ID, err)
+			}
+		}
+	}
+	s.setReady(true)
+	return nil
+}
+
+//Stop stops all Stoppable modules in reverse registration
+//order, giving up on any remaining modules after timeout
+func (s *Modules) Stop(timeout time.Duration) error {
+	s.setReady(false)
+	defer s.series.Stop()
+	deadline := time.After(timeout)
+	for i := len(s.order) - 1; i >= 0; i-- {
+		module := s.order[i]
+		stoppable, ok := module.raw.(Stoppable)
+		if !ok || !module.isEnabled() {
+			continue
+		}
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- stoppable.Stop()
+		}()
+		select {
+		case err := <-stopped:
+			if err != nil {
+				module.logger.Error("failed to stop", "err", err)
+			}
+		case <-deadline:
+			return fmt.Errorf("stop %s: timed out after %s", module.ID, timeout)
+		}
+	}
+	return nil
 }
 
 func (s *Modules) watchUpdates(module *Module, updates chan interface{}) {
 	for update := range updates {
+		//discard updates while disabled
+		if !module.isEnabled() {
+			continue
+		}
 		module.Status = update
+		s.series.Record(module.ID, update)
+		s.state.Push()
+	}
+}
+
+//setEnabled starts or stops the module, then records its new state
+func (s *Modules) setEnabled(module *Module, enabled bool) error {
+	module.mut.Lock()
+	defer module.mut.Unlock()
+	if module.isEnabled() == enabled {
+		return nil
+	}
+	if enabled {
+		if startable, ok := module.raw.(Startable); ok {
+			if err := startable.Start(); err != nil {
+				return err
+			}
+		}
+		module.markEnabled(true)
+	} else {
+		module.markEnabled(false)
+		if stoppable, ok := module.raw.(Stoppable); ok {
+			if err := stoppable.Stop(); err != nil {
+				return err
+			}
+		}
+		module.Status = nil
+	}
+	return s.dbset(enabledKey(module.ID), []byte(strconv.FormatBool(enabled)))
+}
+
+//loadEnabled finds the stored enabled state, falling back to
+//the "enabled" field modules previously kept in their settings
+func (s *Modules) loadEnabled(id string, toggleable Toggleable) bool {
+	if enabled, err := strconv.ParseBool(string(s.dbget(enabledKey(id)))); err == nil {
+		return enabled
+	}
+	legacy := struct {
+		Enabled *bool `json:"enabled"`
+	}{}
+	if b := s.dbget(id); len(b) > 0 && json.Unmarshal(b, &legacy) == nil && legacy.Enabled != nil {
+		//store it, since migrations may remove the legacy field
+		s.dbset(enabledKey(id), []byte(strconv.FormatBool(*legacy.Enabled)))
+		return *legacy.Enabled
+	}
+	return toggleable.EnabledByDefault()
+}
+
+func (s *Modules) enabledMiddleware(module *Module) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !module.isEnabled() {
+				http.Error(w, "Module disabled: "+module.ID, http.StatusNotFound)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (s *Modules) updateEnabledHandler(module *Module) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var enabled bool
+		if err := json.NewDecoder(r.Body).Decode(&enabled); err != nil {
+			http.Error(w, "Expecting true or false", http.StatusBadRequest)
+			return
+		}
+		if err := s.setEnabled(module, enabled); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		module.logger.Info("updated enabled", "enabled", enabled)
 		s.state.Push()
 	}
 }
@@ -125,6 +385,18 @@ func (s *Modules) getSettingsHandler(module *Module) http.HandlerFunc {
 	}
 }
 
+func (s *Modules) getSchemaHandler(module *Module) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		b, err := json.MarshalIndent(module.schema, "", "  ")
+		if err != nil {
+			http.Error(w, "Schema contains invalid JSON", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/schema+json")
+		w.Write(b)
+	}
+}
+
 func (s *Modules) updateSettingsHandler(module *Module) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		var j json.RawMessage
@@ -132,23 +404,125 @@ func (s *Modules) updateSettingsHandler(module *Module) http.HandlerFunc {
 			http.Error(w, "Expecting valid JSON", http.StatusBadRequest)
 			return
 		}
-		//pass to module
-		if err := module.settable.Set(j); err != nil {
+		module.mut.Lock()
+		defer module.mut.Unlock()
+		if err := s.applySettings(module, j, util.RequestUser(r)); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		//success! store in db
-		if err := s.dbset(module.ID, []byte(j)); err != nil {
-			log.Printf("failed to store: %s: %s", module.ID, err)
+	}
+}
+
+//patchSettingsHandler applies an RFC 7396 JSON merge
+//patch to the module's current settings
+func (s *Modules) patchSettingsHandler(module *Module) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var patch interface{}
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			http.Error(w, "Expecting valid JSON", http.StatusBadRequest)
+			return
 		}
-		module.Settings = module.settable.Get()
-		log.Printf("updated settings: %s: %+v", module.ID, module.Settings)
-		s.state.Push()
+		module.mut.Lock()
+		defer module.mut.Unlock()
+		current, err := json.Marshal(module.settable.Get())
+		if err != nil {
+			http.Error(w, "Settings contain invalid JSON", http.StatusInternalServerError)
+			return
+		}
+		//modules decode over their existing settings, so
+		//removed members must be reset rather than omitted
+		var curr interface{}
+		json.Unmarshal(current, &curr)
+		p, _ := json.Marshal(resetNulls(curr, patch))
+		merged, err := util.MergePatch(current, p)
+		if err != nil {
+			http.Error(w, "Expecting valid JSON", http.StatusBadRequest)
+			return
+		}
+		if err := s.applySettings(module, merged, util.RequestUser(r)); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+}
+
+//applySettings passes j to the module and, once accepted, stores
+//the module's resulting settings as a new revision by user.
+//module.mut must be held.
+func (s *Modules) applySettings(module *Module, j json.RawMessage, user string) error {
+	//placeholders keep existing secrets
+	j, err := unredactSettings(module, j)
+	if err != nil {
+		return err
+	}
+	//pass to module
+	if err := module.settable.Set(j); err != nil {
+		return err
+	}
+	//success! store in db
+	module.Settings = redactedSettings(module)
+	if b, err := json.Marshal(module.settable.Get()); err != nil {
+		module.logger.Error("failed to encode settings", "err", err)
+	} else if err := s.dbsetRevision(module.ID, settingsVersion(module.raw), b, user); err != nil {
+		module.logger.Error("failed to store settings", "err", err)
 	}
+	module.logger.Info("updated settings", "settings", module.Settings)
+	s.state.Push()
+	s.bus.Publish(events.Event{
+		Module: module.ID,
+		Type:   events.SettingsChanged,
+		Data:   events.Change{User: user},
+	})
+	return nil
+}
+
+//resetNulls replaces the null members of patch with
+//the zero value of the corresponding current member
+func resetNulls(current, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	c, _ := current.(map[string]interface{})
+	for k, v := range p {
+		if v == nil {
+			if cv, ok := c[k]; ok {
+				p[k] = zeroOf(cv)
+			}
+		} else {
+			p[k] = resetNulls(c[k], v)
+		}
+	}
+	return p
+}
+
+func zeroOf(v interface{}) interface{} {
+	switch v := v.(type) {
+	case bool:
+		return false
+	case float64:
+		return 0
+	case string:
+		return ""
+	case []interface{}:
+		return []interface{}{}
+	case map[string]interface{}:
+		z := map[string]interface{}{}
+		for k, cv := range v {
+			z[k] = zeroOf(cv)
+		}
+		return z
+	}
+	return nil
 }
 
 var bucketName = []byte("settings")
 
+//enabledKey is the settings bucket key of a module's enabled state
+func enabledKey(id string) string {
+	return id + ".enabled"
+}
+
 func (s *Modules) dbget(key string) (contents []byte) {
 	s.db.View(func(tx *bolt.Tx) error {
 		if b := tx.Bucket(bucketName); b != nil {
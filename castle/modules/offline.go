@@ -0,0 +1,66 @@
+package modules
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/boltdb/bolt"
+)
+
+//The following provide offline access to the settings
+//database, for use while the bot is stopped
+
+//StoredIDs returns the IDs of all modules with stored settings
+func (s *Modules) StoredIDs() ([]string, error) {
+	ids := []string{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucketName)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			//skip the enabled/version keys
+			if id := string(k); !strings.Contains(id, ".") {
+				ids = append(ids, id)
+			}
+			return nil
+		})
+	})
+	sort.Strings(ids)
+	return ids, err
+}
+
+//StoredSettings returns the stored settings of module id
+func (s *Modules) StoredSettings(id string) []byte {
+	return s.dbget(id)
+}
+
+//StoredEnabled returns the stored enabled state of module id
+func (s *Modules) StoredEnabled(id string) string {
+	return string(s.dbget(enabledKey(id)))
+}
+
+//StoreSettings replaces the stored settings of module id,
+//recording a new revision at the existing settings version
+func (s *Modules) StoreSettings(id string, contents []byte, user string) error {
+	return s.dbsetRevision(id, s.storedVersion(id), contents, user)
+}
+
+//DeleteSettings removes the stored settings of module id,
+//along with its enabled state, settings version, log level
+//and settings history, so it will then use its defaults
+func (s *Modules) DeleteSettings(id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(bucketName); b != nil {
+			for _, k := range []string{id, enabledKey(id), versionKey(id), levelKey(id)} {
+				if err := b.Delete([]byte(k)); err != nil {
+					return err
+				}
+			}
+		}
+		if h := tx.Bucket(historyBucketName); h != nil && h.Bucket([]byte(id)) != nil {
+			return h.DeleteBucket([]byte(id))
+		}
+		return nil
+	})
+}
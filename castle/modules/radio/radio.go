@@ -1,10 +1,14 @@
 package radio
 
 import (
-	"log"
+	"errors"
 	"net/http"
 	"strconv"
+	"sync"
 
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/metrics"
 	"github.com/jpillora/go433"
 
 	goji "goji.io"
@@ -17,6 +21,11 @@ func New() *Radio {
 }
 
 type Radio struct {
+	mut      sync.Mutex
+	active   bool
+	bus      *events.Bus
+	log      *logs.Logger
+	sent     *metrics.Counter
 	settings struct {
 	}
 }
@@ -25,6 +34,37 @@ func (rd *Radio) ID() string {
 	return "radio"
 }
 
+func (rd *Radio) EnabledByDefault() bool {
+	return true
+}
+
+func (rd *Radio) Start() error {
+	rd.mut.Lock()
+	rd.active = true
+	rd.mut.Unlock()
+	return nil
+}
+
+//Stop refuses sends until started again
+func (rd *Radio) Stop() error {
+	rd.mut.Lock()
+	rd.active = false
+	rd.mut.Unlock()
+	return nil
+}
+
+func (rd *Radio) SetLogger(l *logs.Logger) {
+	rd.log = l
+}
+
+func (rd *Radio) SetBus(bus *events.Bus) {
+	rd.bus = bus
+}
+
+func (rd *Radio) SetMetrics(reg *metrics.Registry) {
+	rd.sent = reg.Counter("castle_radio_sends_total", "Radio codes sent, by result", "result")
+}
+
 func (rd *Radio) RegisterRoutes(mux *goji.Mux) {
 	mux.Handle(pat.Get("/send"), http.HandlerFunc(rd.send))
 }
@@ -35,11 +75,31 @@ func (rd *Radio) send(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid code", 400)
 		return
 	}
-	if err := go433.Send(17, uint32(code)); err != nil {
-		log.Printf("[radio] send error: %s", err)
-		http.Error(w, "send failed", 400)
+	if err := rd.Send(uint32(code)); err != nil {
+		http.Error(w, "send failed: "+err.Error(), 400)
 		return
 	}
-	log.Printf("[radio] sent: %d", code)
 	w.Write([]byte("success"))
 }
+
+//Send transmits code on the radio, one code at a time
+func (rd *Radio) Send(code uint32) error {
+	rd.mut.Lock()
+	defer rd.mut.Unlock()
+	if !rd.active {
+		return errors.New("Radio not active")
+	}
+	if err := go433.Send(17, code); err != nil {
+		rd.log.Error("send failed", "code", code, "err", err)
+		rd.sent.Inc("error")
+		return err
+	}
+	rd.log.Info("sent", "code", code)
+	rd.sent.Inc("ok")
+	rd.bus.Publish(events.Event{
+		Module: rd.ID(),
+		Type:   events.RadioSent,
+		Data:   events.Radio{Code: code},
+	})
+	return nil
+}
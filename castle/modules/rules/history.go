@@ -0,0 +1,90 @@
+package rules
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/events"
+)
+
+//Execution records a single firing of a rule
+type Execution struct {
+	Seq     uint64        `json:"seq"`
+	Rule    string        `json:"rule"`
+	Name    string        `json:"name"`
+	Time    time.Time     `json:"time"`
+	Event   *events.Event `json:"event,omitempty"`
+	Results []string      `json:"results"`
+	Failed  bool          `json:"failed,omitempty"`
+}
+
+//record stores x, trimming the oldest executions
+func (r *Rules) record(x *Execution) error {
+	return r.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(historyBucket)
+		if err != nil {
+			return err
+		}
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+		x.Seq = seq
+		v, err := json.Marshal(x)
+		if err != nil {
+			return err
+		}
+		if err := b.Put(seqKey(seq), v); err != nil {
+			return err
+		}
+		//collect before deleting, deleting
+		//while iterating skips entries
+		old := [][]byte{}
+		n := 0
+		c := b.Cursor()
+		for k, _ := c.Last(); k != nil; k, _ = c.Prev() {
+			n++
+			if n > r.settings.History {
+				old = append(old, append([]byte{}, k...))
+			}
+		}
+		for _, k := range old {
+			if err := b.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+//history returns the executions of rule id
+//(all rules when empty), newest first
+func (r *Rules) history(id string) ([]*Execution, error) {
+	list := []*Execution{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(historyBucket)
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Last(); k != nil; k, v = c.Prev() {
+			x := &Execution{}
+			if err := json.Unmarshal(v, x); err != nil {
+				continue
+			}
+			if id == "" || x.Rule == id {
+				list = append(list, x)
+			}
+		}
+		return nil
+	})
+	return list, err
+}
+
+func seqKey(seq uint64) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, seq)
+	return k
+}
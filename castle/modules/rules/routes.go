@@ -0,0 +1,120 @@
+package rules
+
+import (
+	"encoding/json"
+	"net/http"
+
+	goji "goji.io"
+	"goji.io/pat"
+)
+
+func (r *Rules) RegisterRoutes(mux *goji.Mux) {
+	mux.Handle(pat.Get("/rules"), http.HandlerFunc(r.listRules))
+	mux.Handle(pat.Post("/rules"), http.HandlerFunc(r.createRule))
+	mux.Handle(pat.Get("/rules/:id"), http.HandlerFunc(r.getRule))
+	mux.Handle(pat.Put("/rules/:id"), http.HandlerFunc(r.updateRule))
+	mux.Handle(pat.Delete("/rules/:id"), http.HandlerFunc(r.deleteRule))
+	mux.Handle(pat.Post("/rules/:id/run"), http.HandlerFunc(r.runRule))
+	mux.Handle(pat.Get("/executions"), http.HandlerFunc(r.getHistory))
+}
+
+func (r *Rules) listRules(w http.ResponseWriter, req *http.Request) {
+	writeJSON(w, http.StatusOK, r.list())
+}
+
+func (r *Rules) getRule(w http.ResponseWriter, req *http.Request) {
+	rule := r.get(pat.Param(req, "id"))
+	if rule == nil {
+		http.Error(w, "Rule not found", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, rule)
+}
+
+func (r *Rules) createRule(w http.ResponseWriter, req *http.Request) {
+	rule := &Rule{}
+	if err := json.NewDecoder(req.Body).Decode(rule); err != nil {
+		http.Error(w, "Expecting valid JSON", http.StatusBadRequest)
+		return
+	}
+	rule.ID = ""
+	if err := r.save(rule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusCreated, rule)
+}
+
+func (r *Rules) updateRule(w http.ResponseWriter, req *http.Request) {
+	id := pat.Param(req, "id")
+	if r.get(id) == nil {
+		http.Error(w, "Rule not found", http.StatusNotFound)
+		return
+	}
+	rule := &Rule{}
+	if err := json.NewDecoder(req.Body).Decode(rule); err != nil {
+		http.Error(w, "Expecting valid JSON", http.StatusBadRequest)
+		return
+	}
+	rule.ID = id
+	if err := r.save(rule); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	writeJSON(w, http.StatusOK, rule)
+}
+
+func (r *Rules) deleteRule(w http.ResponseWriter, req *http.Request) {
+	id := pat.Param(req, "id")
+	if r.get(id) == nil {
+		http.Error(w, "Rule not found", http.StatusNotFound)
+		return
+	}
+	if err := r.remove(id); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+//runRule executes the actions of a rule immediately,
+//ignoring its trigger, conditions and cooldown
+func (r *Rules) runRule(w http.ResponseWriter, req *http.Request) {
+	rule := r.get(pat.Param(req, "id"))
+	if rule == nil {
+		http.Error(w, "Rule not found", http.StatusNotFound)
+		return
+	}
+	//begin under mut, so Stop waits for it
+	r.mut.Lock()
+	if !r.active {
+		r.mut.Unlock()
+		http.Error(w, "Rules not active", http.StatusServiceUnavailable)
+		return
+	}
+	r.running.Add(1)
+	r.mut.Unlock()
+	defer r.running.Done()
+	writeJSON(w, http.StatusOK, r.execute(rule, nil))
+}
+
+//getHistory lists executions, optionally of ?rule=<id>
+func (r *Rules) getHistory(w http.ResponseWriter, req *http.Request) {
+	list, err := r.history(req.URL.Query().Get("rule"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(b)
+}
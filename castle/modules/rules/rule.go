@@ -0,0 +1,192 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jpillora/castlebot/castle/actions"
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//Rule fires its actions when its trigger
+//occurs and all of its conditions hold
+type Rule struct {
+	ID         string           `json:"id"`
+	Name       string           `json:"name"`
+	Disabled   bool             `json:"disabled,omitempty"`
+	Trigger    Trigger          `json:"trigger"`
+	Conditions []Condition      `json:"conditions,omitempty"`
+	Actions    []actions.Action `json:"actions"`
+	Cooldown   util.Duration    `json:"cooldown,omitempty"`
+}
+
+//Trigger is either an event (optionally matching its data,
+//or crossing a threshold) or a daily time of day
+type Trigger struct {
+	//Event is the type of event, for example host.arrived
+	Event events.Type `json:"event,omitempty"`
+	//Match requires event data fields to equal these values,
+	//for example {"mac":"aa:bb:cc:dd:ee:ff"} or {"pin":"4","high":"true"}
+	Match map[string]string `json:"match,omitempty"`
+	//Field, Above and Below fire when a numeric event data field
+	//crosses into the range, for example {"field":"cpu","above":90}
+	Field string   `json:"field,omitempty"`
+	Above *float64 `json:"above,omitempty"`
+	Below *float64 `json:"below,omitempty"`
+	//At fires daily at the given local time (15:04)
+	At string `json:"at,omitempty"`
+}
+
+//Condition must hold when the trigger occurs
+type Condition struct {
+	//After and Before restrict to a local time window (15:04),
+	//which may wrap past midnight
+	After  string `json:"after,omitempty"`
+	Before string `json:"before,omitempty"`
+	//Days restricts to days of the week (mon, tue, ...)
+	Days []string `json:"days,omitempty"`
+}
+
+func (r *Rule) validate() error {
+	if r.Name == "" {
+		return errors.New("missing name")
+	}
+	t := r.Trigger
+	if (t.Event == "") == (t.At == "") {
+		return errors.New("trigger requires one of event or at")
+	}
+	if t.At != "" {
+		at, err := normalClock(t.At)
+		if err != nil {
+			return fmt.Errorf("trigger: invalid at: %s", t.At)
+		}
+		r.Trigger.At = at
+		if t.Match != nil || t.Field != "" {
+			return errors.New("trigger: at cannot match event data")
+		}
+	}
+	if (t.Field == "") != (t.Above == nil && t.Below == nil) {
+		return errors.New("trigger: field requires above or below")
+	}
+	for i := range r.Conditions {
+		c := &r.Conditions[i]
+		for _, s := range []*string{&c.After, &c.Before} {
+			if *s == "" {
+				continue
+			}
+			clock, err := normalClock(*s)
+			if err != nil {
+				return fmt.Errorf("condition %d: invalid time: %s", i, *s)
+			}
+			*s = clock
+		}
+		for _, d := range c.Days {
+			if _, ok := weekdays[strings.ToLower(d)]; !ok {
+				return fmt.Errorf("condition %d: invalid day: %s", i, d)
+			}
+		}
+	}
+	if len(r.Actions) == 0 {
+		return errors.New("missing actions")
+	}
+	for i, a := range r.Actions {
+		if err := a.Validate(); err != nil {
+			return fmt.Errorf("action %d: %s", i, err)
+		}
+	}
+	return nil
+}
+
+const clockFormat = "15:04"
+
+//normalClock zero-pads times of day so they compare as strings
+func normalClock(s string) (string, error) {
+	t, err := time.Parse(clockFormat, s)
+	if err != nil {
+		return "", err
+	}
+	return t.Format(clockFormat), nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+//matches reports whether the event data satisfies Match,
+//and returns the value of Field when set
+func (t *Trigger) matches(data map[string]interface{}) (bool, float64) {
+	for k, want := range t.Match {
+		v, ok := data[k]
+		if !ok || fmt.Sprint(v) != want {
+			return false, 0
+		}
+	}
+	if t.Field == "" {
+		return true, 0
+	}
+	switch v := data[t.Field].(type) {
+	case float64:
+		return true, v
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return err == nil, f
+	}
+	return false, 0
+}
+
+//inRange reports whether v is within the threshold
+func (t *Trigger) inRange(v float64) bool {
+	if t.Above != nil && v <= *t.Above {
+		return false
+	}
+	if t.Below != nil && v >= *t.Below {
+		return false
+	}
+	return true
+}
+
+//holds reports whether the condition is met at now
+func (c *Condition) holds(now time.Time) bool {
+	if len(c.Days) > 0 {
+		ok := false
+		for _, d := range c.Days {
+			if weekdays[strings.ToLower(d)] == now.Weekday() {
+				ok = true
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	clock := now.Format(clockFormat)
+	switch {
+	case c.After != "" && c.Before != "" && c.After > c.Before:
+		//wraps past midnight
+		return clock >= c.After || clock < c.Before
+	case c.After != "" && clock < c.After:
+		return false
+	case c.Before != "" && clock >= c.Before:
+		return false
+	}
+	return true
+}
+
+//eventData flattens the event data into a generic map
+func eventData(e *events.Event) map[string]interface{} {
+	data := map[string]interface{}{}
+	if b, err := json.Marshal(e.Data); err == nil {
+		json.Unmarshal(b, &data)
+	}
+	return data
+}
@@ -0,0 +1,336 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/actions"
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+var (
+	rulesBucket   = []byte("rules")
+	historyBucket = []byte("rules_history")
+)
+
+func New(db *bolt.DB, runner actions.Runner) *Rules {
+	r := &Rules{db: db, runner: runner}
+	r.rules = map[string]*Rule{}
+	r.fired = map[string]time.Time{}
+	r.inRange = map[string]bool{}
+	if err := r.load(); err != nil {
+		r.log.Error("failed to load", "err", err)
+	}
+	return r
+}
+
+type Rules struct {
+	db      *bolt.DB
+	runner  actions.Runner
+	bus     *events.Bus
+	log     *logs.Logger
+	updates chan interface{}
+	worker  util.Worker
+	running sync.WaitGroup
+	mut     sync.Mutex
+	//active is set while started, executions
+	//only begin while active, guarded by mut
+	active   bool
+	rules    map[string]*Rule
+	fired    map[string]time.Time
+	inRange  map[string]bool
+	settings struct {
+		Timeout util.Duration `json:"timeout" help:"time to wait for webhooks" default:"10s"`
+		History int           `json:"history" help:"number of executions to keep" default:"100"`
+	}
+	status struct {
+		Rules    int        `json:"rules"`
+		FiredAt  *time.Time `json:"firedAt,omitempty"`
+		LastRule string     `json:"lastRule,omitempty"`
+	}
+}
+
+func (r *Rules) ID() string {
+	return "rules"
+}
+
+func (r *Rules) EnabledByDefault() bool {
+	return false
+}
+
+func (r *Rules) SetLogger(l *logs.Logger) {
+	r.log = l
+}
+
+func (r *Rules) SetBus(bus *events.Bus) {
+	r.bus = bus
+}
+
+func (r *Rules) Start() error {
+	r.mut.Lock()
+	r.active = true
+	r.mut.Unlock()
+	r.worker.Start(r.check)
+	return nil
+}
+
+//Stop waits for executing rules to finish
+func (r *Rules) Stop() error {
+	r.mut.Lock()
+	r.active = false
+	r.mut.Unlock()
+	r.worker.Stop()
+	r.running.Wait()
+	return nil
+}
+
+//check fires rules from events and the clock
+func (r *Rules) check(done <-chan struct{}) {
+	sub := r.bus.Subscribe("")
+	defer sub.Close()
+	clock := time.NewTimer(untilMinute(time.Now()))
+	defer clock.Stop()
+	for {
+		select {
+		case e, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			r.handleEvent(&e)
+		case now := <-clock.C:
+			clock.Reset(untilMinute(now))
+			r.handleClock(now)
+		case <-done:
+			return
+		}
+	}
+}
+
+func (r *Rules) handleEvent(e *events.Event) {
+	data := eventData(e)
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	for _, rule := range r.rules {
+		t := &rule.Trigger
+		if rule.Disabled || t.Event != e.Type {
+			continue
+		}
+		ok, v := t.matches(data)
+		if !ok {
+			continue
+		}
+		if t.Field != "" {
+			//only fire when crossing into the range
+			was := r.inRange[rule.ID]
+			now := t.inRange(v)
+			r.inRange[rule.ID] = now
+			if was || !now {
+				continue
+			}
+		}
+		r.fire(rule, e)
+	}
+}
+
+func (r *Rules) handleClock(now time.Time) {
+	clock := now.Format(clockFormat)
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	for _, rule := range r.rules {
+		if !rule.Disabled && rule.Trigger.At == clock {
+			r.fire(rule, nil)
+		}
+	}
+}
+
+//fire executes the rule if its conditions hold and
+//it is not cooling down. The caller must hold mut.
+func (r *Rules) fire(rule *Rule, e *events.Event) {
+	if !r.active {
+		return
+	}
+	now := time.Now()
+	for _, c := range rule.Conditions {
+		if !c.holds(now) {
+			return
+		}
+	}
+	if last, ok := r.fired[rule.ID]; ok && now.Sub(last) < rule.Cooldown.D() {
+		return
+	}
+	r.fired[rule.ID] = now
+	r.status.FiredAt = &now
+	r.status.LastRule = rule.ID
+	r.running.Add(1)
+	go func(rule Rule) {
+		defer r.running.Done()
+		r.execute(&rule, e)
+		r.push()
+	}(*rule)
+}
+
+//execute performs all actions of rule,
+//recording the results in the history
+func (r *Rules) execute(rule *Rule, e *events.Event) *Execution {
+	x := &Execution{
+		Rule:  rule.ID,
+		Name:  rule.Name,
+		Time:  time.Now(),
+		Event: e,
+	}
+	x.Results, x.Failed = r.runner.RunAll(rule.Actions, map[string]interface{}{
+		"rule":  rule.ID,
+		"name":  rule.Name,
+		"event": e,
+	})
+	r.log.Info("fired rule", "rule", rule.ID, "name", rule.Name, "results", x.Results, "failed", x.Failed)
+	if err := r.record(x); err != nil {
+		r.log.Error("failed to record", "rule", rule.ID, "err", err)
+	}
+	return x
+}
+
+//untilMinute is the time until the minute after now
+func untilMinute(now time.Time) time.Duration {
+	return now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+}
+
+//load reads all rules from the database
+func (r *Rules) load() error {
+	rules := map[string]*Rule{}
+	err := r.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(rulesBucket)
+		if b == nil {
+			return nil
+		}
+		return b.ForEach(func(k, v []byte) error {
+			rule := &Rule{}
+			if err := json.Unmarshal(v, rule); err != nil {
+				r.log.Warn("invalid rule", "key", string(k), "err", err)
+				return nil
+			}
+			rules[rule.ID] = rule
+			return nil
+		})
+	})
+	r.mut.Lock()
+	r.rules = rules
+	r.status.Rules = len(rules)
+	r.mut.Unlock()
+	return err
+}
+
+//list returns all rules ordered by name
+func (r *Rules) list() []*Rule {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	list := []*Rule{}
+	for _, rule := range r.rules {
+		list = append(list, rule)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+func (r *Rules) get(id string) *Rule {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.rules[id]
+}
+
+//save validates and stores rule, assigning
+//an ID to new rules
+func (r *Rules) save(rule *Rule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	if err := r.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists(rulesBucket)
+		if err != nil {
+			return err
+		}
+		if rule.ID == "" {
+			n, err := b.NextSequence()
+			if err != nil {
+				return err
+			}
+			rule.ID = fmt.Sprintf("%d", n)
+		}
+		v, err := json.Marshal(rule)
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(rule.ID), v)
+	}); err != nil {
+		return err
+	}
+	r.mut.Lock()
+	r.rules[rule.ID] = rule
+	delete(r.inRange, rule.ID)
+	r.status.Rules = len(r.rules)
+	r.mut.Unlock()
+	r.push()
+	return nil
+}
+
+func (r *Rules) remove(id string) error {
+	if err := r.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(rulesBucket)
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(id))
+	}); err != nil {
+		return err
+	}
+	r.mut.Lock()
+	delete(r.rules, id)
+	delete(r.fired, id)
+	delete(r.inRange, id)
+	r.status.Rules = len(r.rules)
+	r.mut.Unlock()
+	r.push()
+	return nil
+}
+
+func (r *Rules) Status(updates chan interface{}) {
+	r.updates = updates
+	r.push()
+}
+
+func (r *Rules) push() {
+	if r.updates != nil {
+		r.mut.Lock()
+		status := r.status
+		r.mut.Unlock()
+		r.updates <- &status
+	}
+}
+
+func (r *Rules) Get() interface{} {
+	return &r.settings
+}
+
+func (r *Rules) Set(j json.RawMessage) error {
+	if j != nil {
+		if err := json.Unmarshal(j, &r.settings); err != nil {
+			return err
+		}
+	}
+	if r.settings.Timeout <= 0 {
+		r.settings.Timeout = util.Duration(10 * time.Second)
+	}
+	r.runner.Timeout = r.settings.Timeout.D()
+	if r.settings.History <= 0 {
+		r.settings.History = 100
+	}
+	return nil
+}
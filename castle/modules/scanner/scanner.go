@@ -2,13 +2,16 @@ package scanner
 
 import (
 	"encoding/json"
-	"log"
+	"fmt"
 	"net"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/jpillora/backoff"
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/metrics"
 	"github.com/jpillora/castlebot/castle/util"
 	"github.com/jpillora/icmpscan"
 )
@@ -20,31 +23,44 @@ type host struct {
 	RTT      time.Duration `json:"rtt,omitempty"`
 	SeenAt   time.Time     `json:"seenAt"`
 	ActiveAt time.Time     `json:"activeAt"`
+	//left is set once the host is unseen for the threshold
+	left bool
+}
+
+func (h *host) event() events.Host {
+	return events.Host{IP: h.IP.String(), MAC: h.MAC, Hostname: h.Hostname}
 }
 
 func New() *Scanner {
 	s := &Scanner{}
 	s.timer = time.NewTimer(time.Duration(0))
 	s.timer.Stop()
-	s.settings.Enabled = false
 	s.results.Hosts = map[string]*host{}
-	go s.check()
 	return s
 }
 
 type Scanner struct {
-	updates  chan interface{}
-	timer    *time.Timer
+	updates chan interface{}
+	bus     *events.Bus
+	log     *logs.Logger
+	worker  util.Worker
+	timer   *time.Timer
+	started time.Time
+	metrics struct {
+		hosts    *metrics.Gauge
+		duration *metrics.Histogram
+		failures *metrics.Counter
+	}
 	settings struct {
-		Enabled           bool          `json:"enabled"`
 		Debug             bool          `json:"-"`
-		Interval          util.Duration `json:"interval"`
-		ActiveAtThreshold util.Duration `json:"threshold"`
+		Interval          util.Duration `json:"interval" help:"time between network scans" default:"2m"`
+		ActiveAtThreshold util.Duration `json:"threshold" help:"time a host must be unseen before it is considered newly active" default:"15m"`
 	}
 	results struct {
 		sync.Mutex
 		Scanning  bool             `json:"scanning"`
 		ScannedAt time.Time        `json:"scannedAt"`
+		Present   int              `json:"present"`
 		Hosts     map[string]*host `json:"hosts"`
 	}
 }
@@ -53,27 +69,50 @@ func (sc *Scanner) ID() string {
 	return "scanner"
 }
 
-func (sc *Scanner) check() {
+func (sc *Scanner) EnabledByDefault() bool {
+	return false
+}
+
+func (sc *Scanner) Start() error {
+	sc.started = time.Now()
+	sc.worker.Start(sc.check)
+	return nil
+}
+
+func (sc *Scanner) Stop() error {
+	sc.worker.Stop()
+	return nil
+}
+
+func (sc *Scanner) check(done <-chan struct{}) {
 	b := backoff.Backoff{Max: 5 * time.Minute}
+	wait := time.Duration(0)
 	for {
-		//wait here for <interval>
+		//wait here for <interval> (or backoff after failures)
 		//short-circuited by Set()
-		sc.timer.Reset(sc.settings.Interval.D())
-		<-sc.timer.C
+		sc.timer.Reset(sc.settings.Interval.D() + wait)
+		select {
+		case <-sc.timer.C:
+		case <-done:
+			sc.timer.Stop()
+			return
+		}
 		//scan!
-		if err := sc.scan(); err != nil {
-			log.Printf("[scanner] failed: %s", err)
-			time.Sleep(b.Duration())
+		t0 := time.Now()
+		err := sc.scan()
+		sc.metrics.duration.Observe(time.Since(t0).Seconds())
+		if err != nil {
+			sc.metrics.failures.Inc()
+			wait = b.Duration()
+			sc.log.Error("scan failed", "err", err, "retry", wait)
 		} else {
 			b.Reset()
+			wait = 0
 		}
 	}
 }
 
 func (sc *Scanner) scan() error {
-	if !sc.settings.Enabled {
-		return nil
-	}
 	//show scan state
 	sc.results.Scanning = true
 	sc.push()
@@ -126,18 +165,67 @@ func (sc *Scanner) scan() error {
 		}
 		//calculate seen
 		if h.SeenAt.IsZero() {
-			log.Printf("[scanner] found host: %s", ih.IP)
+			sc.log.Info("found host", "ip", ih.IP)
 		}
 		if now.Sub(h.SeenAt) > sc.settings.ActiveAtThreshold.D() {
 			h.ActiveAt = now
+			sc.publish(events.HostArrived, h)
 		}
 		h.SeenAt = now
+		h.left = false
+	}
+	//announce hosts which have been gone too long
+	for _, h := range sc.results.Hosts {
+		if !h.left && now.Sub(h.SeenAt) > sc.settings.ActiveAtThreshold.D() {
+			h.left = true
+			sc.publish(events.HostLeft, h)
+		}
+	}
+	present := 0
+	for _, h := range sc.results.Hosts {
+		if !h.left {
+			present++
+		}
 	}
+	sc.results.Present = present
+	sc.metrics.hosts.Set(float64(present), "present")
+	sc.metrics.hosts.Set(float64(len(sc.results.Hosts)-present), "left")
 	sc.results.ScannedAt = now
 	sc.push()
 	return nil
 }
 
+//Health fails once a scan has not completed for two
+//intervals, allowing a minute for the scan itself
+func (sc *Scanner) Health() error {
+	last := sc.results.ScannedAt
+	if last.Before(sc.started) {
+		last = sc.started
+	}
+	if since := time.Since(last); since > 2*sc.settings.Interval.D()+time.Minute {
+		return fmt.Errorf("last scan completed %s ago", since.Round(time.Second))
+	}
+	return nil
+}
+
+func (sc *Scanner) SetLogger(l *logs.Logger) {
+	sc.log = l
+}
+
+func (sc *Scanner) SetBus(bus *events.Bus) {
+	sc.bus = bus
+}
+
+func (sc *Scanner) SetMetrics(reg *metrics.Registry) {
+	sc.metrics.hosts = reg.Gauge("castle_scanner_hosts", "Hosts found on the network, by state", "state")
+	sc.metrics.duration = reg.Histogram("castle_scanner_scan_duration_seconds", "Time taken by network scans", []float64{1, 2, 5, 10, 20, 30, 60})
+	sc.metrics.failures = reg.Counter("castle_scanner_scan_failures_total", "Failed network scans")
+}
+
+func (sc *Scanner) publish(t events.Type, h *host) {
+	sc.bus.Publish(events.Event{Module: sc.ID(), Type: t, Data: h.event()})
+}
+
 func (sc *Scanner) Status(updates chan interface{}) {
 	sc.updates = updates
 	sc.push()
@@ -149,6 +237,17 @@ func (sc *Scanner) push() {
 	}
 }
 
+func (sc *Scanner) Migrations() []func(map[string]interface{}) error {
+	return []func(map[string]interface{}) error{
+		//v1: durations as strings, enabled moved to the module container
+		func(s map[string]interface{}) error {
+			util.MigrateSeconds(s, "interval", "threshold")
+			delete(s, "enabled")
+			return nil
+		},
+	}
+}
+
 func (sc *Scanner) Get() interface{} {
 	return &sc.settings
 }
@@ -0,0 +1,120 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"* * * foo *",
+		"@never",
+	} {
+		if _, err := parseCron(s); err == nil {
+			t.Errorf("parseCron(%q) expected an error", s)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	//2024-01-01 is a monday
+	from := time.Date(2024, 1, 1, 10, 30, 15, 0, time.UTC)
+	for _, test := range []struct {
+		cron string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{"30 * * * *", time.Date(2024, 1, 1, 11, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"10/20 * * * *", time.Date(2024, 1, 1, 10, 50, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{"0,45 10 * * *", time.Date(2024, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 6 * * *", time.Date(2024, 1, 2, 6, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		//names and sunday as 7
+		{"0 8 * * sat", time.Date(2024, 1, 6, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * 7", time.Date(2024, 1, 7, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * MON-FRI", time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)},
+		{"0 0 1 jun *", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		//leap days
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		//either day field matches when neither is a star
+		{"0 0 15 * fri", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"0 0 3 * fri", time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		//both must match when either is a star
+		{"0 0 13 * *", time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC)},
+		{"0 0 */10 * *", time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * 3 fri", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		//never
+		{"0 0 31 2 *", time.Time{}},
+	} {
+		c, err := parseCron(test.cron)
+		if err != nil {
+			t.Errorf("parseCron(%q): %s", test.cron, err)
+			continue
+		}
+		if next := c.next(from); !next.Equal(test.next) {
+			t.Errorf("%q next = %s, want %s", test.cron, next, test.next)
+		}
+	}
+}
+
+func TestSunTimes(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		day       time.Time
+		lat, long float64
+		rise, set time.Time
+	}{
+		{
+			"london midsummer",
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), 51.5074, -0.1278,
+			time.Date(2024, 6, 21, 3, 43, 0, 0, time.UTC),
+			time.Date(2024, 6, 21, 20, 21, 0, 0, time.UTC),
+		},
+		{
+			"sydney midwinter",
+			time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), -33.8688, 151.2093,
+			time.Date(2024, 6, 20, 20, 59, 0, 0, time.UTC),
+			time.Date(2024, 6, 21, 6, 54, 0, 0, time.UTC),
+		},
+	} {
+		rise, set, ok := sunTimes(test.day, test.lat, test.long)
+		if !ok {
+			t.Errorf("%s: expected a sunrise and sunset", test.name)
+			continue
+		}
+		if d := rise.Sub(test.rise); d < -3*time.Minute || d > 3*time.Minute {
+			t.Errorf("%s: sunrise %s, want %s", test.name, rise, test.rise)
+		}
+		if d := set.Sub(test.set); d < -3*time.Minute || d > 3*time.Minute {
+			t.Errorf("%s: sunset %s, want %s", test.name, set, test.set)
+		}
+	}
+	//polar day and night
+	for _, day := range []time.Time{
+		time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+		time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC),
+	} {
+		if _, _, ok := sunTimes(day, 78.2232, 15.6267); ok {
+			t.Errorf("svalbard %s: expected no sunrise or sunset", day.Format("2006-01-02"))
+		}
+	}
+}
@@ -0,0 +1,348 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jpillora/castlebot/castle/actions"
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//Job performs its actions on a cron schedule,
+//or daily relative to sunrise or sunset
+type Job struct {
+	Name    string           `json:"name"`
+	Cron    string           `json:"cron,omitempty" help:"cron expression (minute hour day month weekday) or @daily, @hourly, etc"`
+	Sun     string           `json:"sun,omitempty" help:"sunrise or sunset, instead of cron"`
+	Offset  util.Duration    `json:"offset,omitempty" help:"time relative to sunrise or sunset, such as -30m"`
+	Actions []actions.Action `json:"actions"`
+}
+
+//job is a parsed Job and its run state
+type job struct {
+	Job
+	cron *cron
+	//state
+	next    time.Time
+	last    time.Time
+	results []string
+	failed  bool
+}
+
+type jobStatus struct {
+	Name    string     `json:"name"`
+	Next    *time.Time `json:"next,omitempty"`
+	Last    *time.Time `json:"last,omitempty"`
+	Results []string   `json:"results,omitempty"`
+	Failed  bool       `json:"failed,omitempty"`
+}
+
+func New(runner actions.Runner) *Scheduler {
+	s := &Scheduler{runner: runner}
+	s.timer = time.NewTimer(time.Duration(0))
+	s.timer.Stop()
+	return s
+}
+
+type Scheduler struct {
+	runner   actions.Runner
+	bus      *events.Bus
+	log      *logs.Logger
+	updates  chan interface{}
+	worker   util.Worker
+	running  sync.WaitGroup
+	timer    *time.Timer
+	mut      sync.Mutex
+	jobs     []*job
+	settings settings
+}
+
+type settings struct {
+	Latitude  float64       `json:"latitude" help:"latitude for sunrise and sunset, north positive"`
+	Longitude float64       `json:"longitude" help:"longitude for sunrise and sunset, east positive"`
+	Timeout   util.Duration `json:"timeout" help:"time to wait for webhooks" default:"10s"`
+	Jobs      []Job         `json:"jobs"`
+}
+
+func (s *Scheduler) ID() string {
+	return "scheduler"
+}
+
+func (s *Scheduler) EnabledByDefault() bool {
+	return false
+}
+
+func (s *Scheduler) SetLogger(l *logs.Logger) {
+	s.log = l
+}
+
+func (s *Scheduler) SetBus(bus *events.Bus) {
+	s.bus = bus
+}
+
+func (s *Scheduler) Start() error {
+	s.worker.Start(s.check)
+	return nil
+}
+
+//Stop waits for running jobs to finish
+func (s *Scheduler) Stop() error {
+	s.worker.Stop()
+	s.running.Wait()
+	return nil
+}
+
+func (s *Scheduler) check(done <-chan struct{}) {
+	for {
+		//wait here until the next job is due,
+		//short-circuited by Set()
+		s.timer.Reset(s.untilNext(time.Now()))
+		select {
+		case <-s.timer.C:
+		case <-done:
+			s.timer.Stop()
+			return
+		}
+		s.runDue(time.Now())
+	}
+}
+
+//untilNext is the time until the next job is due, rechecked
+//at least hourly in case the clock changes
+func (s *Scheduler) untilNext(now time.Time) time.Duration {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	wait := time.Hour
+	for _, j := range s.jobs {
+		if !j.next.IsZero() && j.next.Sub(now) < wait {
+			wait = j.next.Sub(now)
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+//runDue runs all jobs due at now
+func (s *Scheduler) runDue(now time.Time) {
+	s.mut.Lock()
+	ran := false
+	for _, j := range s.jobs {
+		if j.next.IsZero() || j.next.After(now) {
+			continue
+		}
+		ran = true
+		j.last = now
+		j.next = s.nextRun(j, now)
+		s.running.Add(1)
+		go s.run(j)
+	}
+	s.mut.Unlock()
+	if ran {
+		s.push()
+	}
+}
+
+func (s *Scheduler) run(j *job) {
+	defer s.running.Done()
+	s.mut.Lock()
+	runner := s.runner
+	s.mut.Unlock()
+	results, failed := runner.RunAll(j.Actions, map[string]interface{}{
+		"job": j.Name,
+	})
+	s.log.Info("ran job", "job", j.Name, "results", results, "failed", failed)
+	s.mut.Lock()
+	j.results = results
+	j.failed = failed
+	s.mut.Unlock()
+	s.bus.Publish(events.Event{
+		Module: s.ID(),
+		Type:   events.JobRun,
+		Data:   events.Job{Name: j.Name, Results: results},
+	})
+	s.push()
+}
+
+//nextRun is the time j should next run after now,
+//or the zero time when it never runs
+func (s *Scheduler) nextRun(j *job, now time.Time) time.Time {
+	if j.cron != nil {
+		return j.cron.next(now)
+	}
+	//search ahead for a day with a sunrise/sunset,
+	//starting with yesterday in case of a large offset
+	for i := -1; i <= 366; i++ {
+		day := time.Date(now.Year(), now.Month(), now.Day()+i, 12, 0, 0, 0, now.Location())
+		rise, set, ok := sunTimes(day, s.settings.Latitude, s.settings.Longitude)
+		if !ok {
+			continue
+		}
+		t := rise
+		if j.Sun == "sunset" {
+			t = set
+		}
+		t = t.Add(j.Offset.D())
+		if t.After(now) {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+func (s *Scheduler) Status(updates chan interface{}) {
+	s.updates = updates
+	s.push()
+}
+
+func (s *Scheduler) push() {
+	if s.updates == nil {
+		return
+	}
+	s.mut.Lock()
+	status := struct {
+		Jobs []jobStatus `json:"jobs"`
+	}{
+		Jobs: []jobStatus{},
+	}
+	for _, j := range s.jobs {
+		js := jobStatus{Name: j.Name, Results: j.results, Failed: j.failed}
+		if !j.next.IsZero() {
+			next := j.next
+			js.Next = &next
+		}
+		if !j.last.IsZero() {
+			last := j.last
+			js.Last = &last
+		}
+		status.Jobs = append(status.Jobs, js)
+	}
+	s.mut.Unlock()
+	s.updates <- &status
+}
+
+//Validate checks j without applying it
+func (s *Scheduler) Validate(j json.RawMessage) error {
+	_, _, err := s.parse(j)
+	return err
+}
+
+//parse decodes j over a copy of the current settings,
+//returning them along with their parsed jobs
+func (s *Scheduler) parse(j json.RawMessage) (settings, []*job, error) {
+	c := s.settings
+	if j != nil {
+		//decode jobs afresh, json would otherwise reuse the
+		//existing jobs, leaking their fields into the new ones
+		members := map[string]json.RawMessage{}
+		if err := json.Unmarshal(j, &members); err != nil {
+			return c, nil, err
+		}
+		if _, ok := members["jobs"]; ok {
+			c.Jobs = nil
+		}
+		if err := json.Unmarshal(j, &c); err != nil {
+			return c, nil, err
+		}
+	}
+	if c.Latitude < -90 || c.Latitude > 90 {
+		return c, nil, errors.New("Latitude must be between -90 and 90")
+	}
+	if c.Longitude < -180 || c.Longitude > 180 {
+		return c, nil, errors.New("Longitude must be between -180 and 180")
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = util.Duration(10 * time.Second)
+	}
+	if c.Jobs == nil {
+		c.Jobs = []Job{}
+	}
+	jobs := []*job{}
+	for i, jb := range c.Jobs {
+		parsed, err := parseJob(jb)
+		if err != nil {
+			return c, nil, fmt.Errorf("Job %d: %s", i, err)
+		}
+		jobs = append(jobs, parsed)
+	}
+	if len(jobs) > 0 && c.Latitude == 0 && c.Longitude == 0 {
+		for _, jb := range jobs {
+			if jb.cron == nil {
+				return c, nil, errors.New("Latitude and longitude are required for sunrise and sunset")
+			}
+		}
+	}
+	return c, jobs, nil
+}
+
+func (s *Scheduler) Get() interface{} {
+	return &s.settings
+}
+
+func (s *Scheduler) Set(j json.RawMessage) error {
+	settings, jobs, err := s.parse(j)
+	if err != nil {
+		return err
+	}
+	//apply
+	s.mut.Lock()
+	s.settings = settings
+	s.runner.Timeout = settings.Timeout.D()
+	//keep the run state of unchanged jobs
+	prev := map[string]*job{}
+	for _, jb := range s.jobs {
+		prev[jb.Name] = jb
+	}
+	now := time.Now()
+	for _, jb := range jobs {
+		if p, ok := prev[jb.Name]; ok {
+			jb.last, jb.results, jb.failed = p.last, p.results, p.failed
+		}
+		jb.next = s.nextRun(jb, now)
+	}
+	s.jobs = jobs
+	s.mut.Unlock()
+	s.timer.Reset(0)
+	s.push()
+	return nil
+}
+
+func parseJob(jb Job) (*job, error) {
+	if jb.Name == "" {
+		return nil, errors.New("missing name")
+	}
+	j := &job{Job: jb}
+	switch {
+	case jb.Cron != "" && jb.Sun != "":
+		return nil, errors.New("expected one of cron or sun")
+	case jb.Cron != "":
+		c, err := parseCron(jb.Cron)
+		if err != nil {
+			return nil, err
+		}
+		j.cron = c
+	case jb.Sun != "":
+		j.Sun = strings.ToLower(jb.Sun)
+		if j.Sun != "sunrise" && j.Sun != "sunset" {
+			return nil, errors.New("sun must be sunrise or sunset")
+		}
+	default:
+		return nil, errors.New("missing cron or sun")
+	}
+	if len(jb.Actions) == 0 {
+		return nil, errors.New("missing actions")
+	}
+	for i, a := range jb.Actions {
+		if err := a.Validate(); err != nil {
+			return nil, fmt.Errorf("action %d: %s", i, err)
+		}
+	}
+	return j, nil
+}
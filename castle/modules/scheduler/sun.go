@@ -0,0 +1,61 @@
+package scheduler
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	//julian date of 2000-01-01 12:00 UTC
+	j2000 = 2451545.0
+	//julian date of the unix epoch
+	jUnix = 2440587.5
+	//sun altitude at sunrise/sunset, accounting for
+	//refraction and the sun's apparent radius
+	sunAltitude = -0.833
+	//obliquity of the ecliptic
+	obliquity = 23.4397
+)
+
+//sunTimes computes sunrise and sunset on the date of day at lat/long
+//(degrees, north and east positive) using the sunrise equation.
+//ok is false during polar day and night.
+func sunTimes(day time.Time, lat, long float64) (rise, set time.Time, ok bool) {
+	//days since j2000 to noon (UTC) of the date
+	noon := time.Date(day.Year(), day.Month(), day.Day(), 12, 0, 0, 0, time.UTC)
+	n := math.Floor(float64(noon.Unix())/86400+jUnix-j2000) + 0.0008
+	//mean solar noon
+	jstar := n - long/360
+	//solar mean anomaly
+	m := math.Mod(357.5291+0.98560028*jstar, 360)
+	//equation of the center
+	c := 1.9148*sin(m) + 0.0200*sin(2*m) + 0.0003*sin(3*m)
+	//ecliptic longitude
+	l := math.Mod(m+c+180+102.9372, 360)
+	//solar transit
+	transit := j2000 + jstar + 0.0053*sin(m) - 0.0069*sin(2*l)
+	//declination
+	sinDecl := sin(l) * sin(obliquity)
+	cosDecl := math.Cos(math.Asin(sinDecl))
+	//hour angle
+	cosH := (sin(sunAltitude) - sin(lat)*sinDecl) / (cos(lat) * cosDecl)
+	if cosH < -1 || cosH > 1 {
+		return time.Time{}, time.Time{}, false
+	}
+	h := math.Acos(cosH) * 180 / math.Pi
+	rise = julianTime(transit - h/360).In(day.Location())
+	set = julianTime(transit + h/360).In(day.Location())
+	return rise, set, true
+}
+
+func julianTime(j float64) time.Time {
+	return time.Unix(0, int64((j-jUnix)*86400*float64(time.Second)))
+}
+
+func sin(deg float64) float64 {
+	return math.Sin(deg * math.Pi / 180)
+}
+
+func cos(deg float64) float64 {
+	return math.Cos(deg * math.Pi / 180)
+}
@@ -0,0 +1,157 @@
+package modules
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+//Schema is a JSON Schema (draft 7) describing
+//the settings of a module. It is derived from the
+//settings struct, using these optional field tags:
+//  help:"<description>"
+//  default:"<value>"
+//  enum:"<value>,<value>,..."
+//  secret:"true"
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	WriteOnly            bool               `json:"writeOnly,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	durationType     = reflect.TypeOf(util.Duration(0))
+	timeDurationType = reflect.TypeOf(time.Duration(0))
+	timeType         = reflect.TypeOf(time.Time{})
+)
+
+//durationPattern matches strings accepted by time.ParseDuration
+const durationPattern = `^[-+]?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$`
+
+func newSchema(id string, settings interface{}) *Schema {
+	s := schemaOf(reflect.TypeOf(settings))
+	s.Schema = "http://json-schema.org/draft-07/schema#"
+	s.Title = id
+	return s
+}
+
+func schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case durationType:
+		return &Schema{Type: "string", Format: "duration", Pattern: durationPattern}
+	case timeDurationType:
+		return &Schema{Type: "integer", Description: "nanoseconds"}
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: schemaOf(t.Elem())}
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+		addFields(s, t)
+		return s
+	}
+	//interfaces, channels, etc
+	return &Schema{}
+}
+
+func addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := jsonName(f)
+		if !ok {
+			continue
+		}
+		//embedded structs are flattened
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addFields(s, ft)
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		p := schemaOf(f.Type)
+		if help := f.Tag.Get("help"); help != "" {
+			p.Description = help
+		}
+		if def, ok := f.Tag.Lookup("default"); ok {
+			p.Default = parseTagValue(p.Type, def)
+		}
+		if secret, _ := strconv.ParseBool(f.Tag.Get("secret")); secret {
+			p.WriteOnly = true
+		}
+		if enum := f.Tag.Get("enum"); enum != "" {
+			for _, v := range strings.Split(enum, ",") {
+				p.Enum = append(p.Enum, parseTagValue(p.Type, strings.TrimSpace(v)))
+			}
+		}
+		s.Properties[name] = p
+	}
+}
+
+//jsonName returns the json property name of f, or
+//false when f is not marshalled
+func jsonName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	//unexported, non-embedded fields are skipped
+	if f.PkgPath != "" && !f.Anonymous {
+		return "", false
+	}
+	name := strings.Split(tag, ",")[0]
+	return name, true
+}
+
+//parseTagValue converts a tag value into its json type
+func parseTagValue(typ, v string) interface{} {
+	switch typ {
+	case "boolean":
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	case "integer":
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	}
+	return v
+}
@@ -0,0 +1,95 @@
+package modules
+
+import "encoding/json"
+
+//secretPlaceholder is shown in place of secret settings,
+//and when sent back, keeps the existing secret value
+const secretPlaceholder = "********"
+
+//redact replaces the non-empty values of the
+//writeOnly (secret) properties within v
+func (s *Schema) redact(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	for k, mv := range m {
+		p, ok := s.Properties[k]
+		if !ok {
+			continue
+		}
+		if p.WriteOnly {
+			if mv != nil && mv != "" {
+				m[k] = secretPlaceholder
+			}
+		} else {
+			m[k] = p.redact(mv)
+		}
+	}
+	return m
+}
+
+//unredact restores the placeholders of the writeOnly
+//(secret) properties within v to their current values
+func (s *Schema) unredact(v, current interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	c, _ := current.(map[string]interface{})
+	for k, mv := range m {
+		p, ok := s.Properties[k]
+		if !ok {
+			continue
+		}
+		if p.WriteOnly {
+			if cv, ok := c[k]; ok && mv == secretPlaceholder {
+				m[k] = cv
+			}
+		} else {
+			m[k] = p.unredact(mv, c[k])
+		}
+	}
+	return m
+}
+
+//redactJSON redacts a settings document
+func (s *Schema) redactJSON(j json.RawMessage) json.RawMessage {
+	var v interface{}
+	if err := json.Unmarshal(j, &v); err != nil {
+		return nil
+	}
+	b, _ := json.Marshal(s.redact(v))
+	return b
+}
+
+//redactedSettings returns the module's current settings
+//with secrets redacted, safe to show to all clients
+func redactedSettings(module *Module) interface{} {
+	b, err := json.Marshal(module.settable.Get())
+	if err != nil {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil
+	}
+	return module.schema.redact(v)
+}
+
+//unredactSettings restores the secrets within j
+//using the module's current settings
+func unredactSettings(module *Module, j json.RawMessage) (json.RawMessage, error) {
+	var v, curr interface{}
+	if err := json.Unmarshal(j, &v); err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(module.settable.Get())
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &curr); err != nil {
+		return nil, err
+	}
+	return json.Marshal(module.schema.unredact(v, curr))
+}
@@ -8,6 +8,12 @@ import (
 
 var bucketName = []byte("amcedb")
 
+//ACMEBucket is the name of the bolt bucket which
+//holds the ACME account, keys and certificates
+func ACMEBucket() string {
+	return string(bucketName)
+}
+
 type acmeDB struct {
 	*bolt.DB
 }
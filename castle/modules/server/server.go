@@ -1,22 +1,24 @@
 package server
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net"
 	"net/http"
 	"sync"
+	"sync/atomic"
 
 	"github.com/boltdb/bolt"
 	"github.com/dkumor/acmewrapper"
+	"github.com/jpillora/castlebot/castle/logs"
 )
 
 func New(db *bolt.DB, root http.Handler, defaultPort int) *Server {
 	s := &Server{
-		running: make(chan error),
+		running: make(chan error, 1),
 		adb:     &acmeDB{DB: db},
 		root:    root,
 	}
@@ -29,26 +31,65 @@ type Server struct {
 	running                     chan error
 	adb                         *acmeDB
 	root                        http.Handler
+	log                         *logs.Logger
 	listenMut                   sync.Mutex
 	httpListener, httpsListener net.Listener
-	Config                      struct {
-		HTTP struct {
-			Host string `json:"host"`
-			Port int    `json:"port"`
-		} `json:"http"`
-		HTTPS struct {
-			Host     string `json:"host"`
-			Port     int    `json:"port"`
-			Hostname string `json:"hostname"`
-			Email    string `json:"email"`
-		} `json:"https"`
+	httpServer, httpsServer     *http.Server
+	serving                     int32
+	Config                      Config
+}
+
+type Config struct {
+	HTTP struct {
+		Host string `json:"host" help:"http listening interface" default:"0.0.0.0"`
+		Port int    `json:"port" help:"http listening port" default:"4000"`
+	} `json:"http"`
+	HTTPS struct {
+		Host     string `json:"host" help:"https listening interface" default:"0.0.0.0"`
+		Port     int    `json:"port" help:"https listening port, enables https along with hostname"`
+		Hostname string `json:"hostname" help:"public hostname used to obtain a certificate via ACME"`
+		Email    string `json:"email" help:"ACME registration email"`
+	} `json:"https"`
+}
+
+//Validate checks j without applying it
+func (s *Server) Validate(j json.RawMessage) error {
+	_, err := s.parse(j)
+	return err
+}
+
+//parse decodes j over a copy of the current config
+func (s *Server) parse(j json.RawMessage) (Config, error) {
+	c := s.Config
+	if j != nil {
+		if err := json.Unmarshal(j, &c); err != nil {
+			return c, err
+		}
+	}
+	//defaults
+	if c.HTTP.Port == 0 {
+		c.HTTP.Port = 4000
+	}
+	if c.HTTP.Host == "" {
+		c.HTTP.Host = "0.0.0.0"
+	}
+	if c.HTTPS.Host == "" {
+		c.HTTPS.Host = "0.0.0.0"
 	}
+	if c.HTTP.Port < 0 || c.HTTP.Port > 65535 || c.HTTPS.Port < 0 || c.HTTPS.Port > 65535 {
+		return c, errors.New("Ports must be between 0 and 65535")
+	}
+	return c, nil
 }
 
 func (s *Server) ID() string {
 	return "server"
 }
 
+func (s *Server) SetLogger(l *logs.Logger) {
+	s.log = l
+}
+
 func (s *Server) listen() error {
 	//only 1 listener at a time
 	s.listenMut.Lock()
@@ -77,7 +118,7 @@ func (s *Server) listen() error {
 		if err != nil {
 			return err
 		}
-		log.Printf("Listening on https://%s:%d", s.Config.HTTPS.Hostname, s.Config.HTTPS.Port)
+		s.log.Info(fmt.Sprintf("Listening on https://%s:%d", s.Config.HTTPS.Hostname, s.Config.HTTPS.Port), "addr", addr)
 		s.httpsListener = l
 	}
 	s.httpListener = nil
@@ -87,7 +128,7 @@ func (s *Server) listen() error {
 		if err != nil {
 			return err
 		}
-		log.Printf("Listening on http://%s", addr)
+		s.log.Info("Listening on http://"+addr, "addr", addr)
 		s.httpListener = l
 	}
 	if s.httpsListener == nil && s.httpListener == nil {
@@ -96,21 +137,27 @@ func (s *Server) listen() error {
 	wg := sync.WaitGroup{}
 	if s.httpsListener != nil {
 		wg.Add(1)
-		go func() {
-			if err := http.Serve(s.httpsListener, s.root); err != nil {
-				log.Printf("https listener: %s", err)
+		s.httpsServer = &http.Server{Handler: s.root}
+		go func(srv *http.Server, l net.Listener) {
+			atomic.AddInt32(&s.serving, 1)
+			defer atomic.AddInt32(&s.serving, -1)
+			if err := srv.Serve(l); err != nil && err != http.ErrServerClosed {
+				s.log.Error("https listener failed", "err", err)
 			}
 			wg.Done()
-		}()
+		}(s.httpsServer, s.httpsListener)
 	}
 	if s.httpListener != nil {
 		wg.Add(1)
-		go func() {
-			if err := http.Serve(s.httpListener, s.root); err != nil {
-				log.Printf("http listener: %s", err)
+		s.httpServer = &http.Server{Handler: s.root}
+		go func(srv *http.Server, l net.Listener) {
+			atomic.AddInt32(&s.serving, 1)
+			defer atomic.AddInt32(&s.serving, -1)
+			if err := srv.Serve(l); err != nil && err != http.ErrServerClosed {
+				s.log.Error("http listener failed", "err", err)
 			}
 			wg.Done()
-		}()
+		}(s.httpServer, s.httpListener)
 	}
 	wg.Wait()
 	return nil
@@ -121,21 +168,11 @@ func (s *Server) Get() interface{} {
 }
 
 func (s *Server) Set(j json.RawMessage) error {
-	if j != nil {
-		if err := json.Unmarshal(j, &s.Config); err != nil {
-			return err
-		}
-	}
-	//defaults
-	if s.Config.HTTP.Port == 0 {
-		s.Config.HTTP.Port = 4000
-	}
-	if s.Config.HTTP.Host == "" {
-		s.Config.HTTP.Host = "0.0.0.0"
-	}
-	if s.Config.HTTPS.Host == "" {
-		s.Config.HTTPS.Host = "0.0.0.0"
+	config, err := s.parse(j)
+	if err != nil {
+		return err
 	}
+	s.Config = config
 	//close existing listeners
 	s.updated = true
 	if s.httpsListener != nil {
@@ -159,8 +196,49 @@ func (s *Server) Set(j json.RawMessage) error {
 	return nil
 }
 
+//Shutdown stops both listeners from accepting new connections
+//and waits for in-flight requests to complete (or ctx to expire),
+//causing Wait to return
+func (s *Server) Shutdown(ctx context.Context) error {
+	var err error
+	for _, srv := range []*http.Server{s.httpsServer, s.httpServer} {
+		if srv == nil {
+			continue
+		}
+		if e := srv.Shutdown(ctx); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+//Close closes both listeners and any active
+//connections immediately, causing Wait to return
 func (s *Server) Close() error {
-	return nil //TODO
+	var err error
+	for _, srv := range []*http.Server{s.httpsServer, s.httpServer} {
+		if srv == nil {
+			continue
+		}
+		if e := srv.Close(); e != nil {
+			err = e
+		}
+	}
+	s.httpsServer = nil
+	s.httpServer = nil
+	return err
+}
+
+//Health fails while no listeners are bound
+func (s *Server) Health() error {
+	if atomic.LoadInt32(&s.serving) == 0 {
+		return errors.New("no listeners bound")
+	}
+	return nil
+}
+
+func (s *Server) Stop() error {
+	return s.Close()
 }
 
 func (s *Server) Wait() error {
@@ -2,73 +2,82 @@ package webcam
 
 import (
 	"bytes"
-	"log"
 	"strings"
+	"sync"
 
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/metrics"
 	dropbox "github.com/jpillora/go-dropbox"
 )
 
 const queueSize = 100
 
 type dropcam struct {
+	mut     sync.Mutex
 	ready   bool
 	base    string
 	client  *dropbox.Client
 	queue   chan *snap
+	drained chan struct{}
 	lastDir string
+	log     *logs.Logger
+	depth   *metrics.Gauge
 }
 
-func newDropcam(api, base string) (*dropcam, error) {
+func newDropcam(api, base string, log *logs.Logger, depth *metrics.Gauge) (*dropcam, error) {
 	//create dropbox client and test authentication
 	client := dropbox.New(dropbox.NewConfig(api))
 	u, err := client.Users.GetCurrentAccount()
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("[dropcam] dropbox user: %s", u.Name)
-	dc := &dropcam{}
+	log.Info("dropbox user", "name", u.Name)
+	dc := &dropcam{log: log, depth: depth}
 	dc.base = base
 	dc.client = client
 	dc.queue = make(chan *snap, queueSize)
+	dc.drained = make(chan struct{})
 	go dc.deque()
 	dc.ready = true
 	return dc, nil
 }
 
 func (w *dropcam) enque(s *snap) bool {
+	w.mut.Lock()
+	defer w.mut.Unlock()
 	if !w.ready || len(w.queue) == queueSize {
 		return false //give up
 	}
 	//should never block
 	w.queue <- s
+	w.depth.Set(float64(len(w.queue)))
 	//enqueued!
 	return true
 }
 
 func (w *dropcam) deque() {
 	for s := range w.queue {
+		w.depth.Set(float64(len(w.queue)))
 		w.upload(s)
 	}
+	close(w.drained)
 }
 
 func (w *dropcam) upload(s *snap) {
-	if !w.ready {
-		return
-	}
 	baseDir := dateDir(w.base, s.t)
 	if baseDir != w.lastDir {
 		if _, err := w.client.Files.CreateFolder(&dropbox.CreateFolderInput{
 			Path: baseDir,
 		}); err == nil {
-			log.Printf("[dropcam] created: %s", baseDir)
+			w.log.Info("dropbox created", "dir", baseDir)
 		} else if !strings.Contains(err.Error(), "path/conflict/folder") {
-			log.Printf("[dropcam] dropbox mkdir fail: %s", err)
+			w.log.Error("dropbox mkdir failed", "dir", baseDir, "err", err)
 			return
 		}
 		w.lastDir = baseDir
 	}
 	filepath := timeJpg(baseDir, s.t)
-	log.Printf("[dropcam] upload: %s", filepath)
+	w.log.Debug("dropbox upload", "file", filepath)
 	_, err := w.client.Files.Upload(&dropbox.UploadInput{
 		Path:       filepath,
 		Mode:       dropbox.WriteModeAdd,
@@ -77,12 +86,24 @@ func (w *dropcam) upload(s *snap) {
 		Reader:     bytes.NewReader(s.raw),
 	})
 	if err != nil {
-		log.Printf("[dropcam] dropbox upload fail: %s", err)
+		w.log.Error("dropbox upload failed", "file", filepath, "err", err)
 	}
-	log.Printf("[dropcam] uploaded. %d remaining", len(w.queue))
+	w.log.Debug("dropbox uploaded", "file", filepath, "remaining", len(w.queue))
 }
 
+//close stops accepting snaps, those already
+//queued continue to upload in the background
 func (w *dropcam) close() {
-	close(w.queue)
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	if !w.ready {
+		return
+	}
 	w.ready = false
+	close(w.queue)
+}
+
+//wait blocks until all queued snaps have been uploaded
+func (w *dropcam) wait() {
+	<-w.drained
 }
@@ -3,7 +3,6 @@ package webcam
 import (
 	"encoding/json"
 	"errors"
-	"log"
 	"net/url"
 	"os"
 	"time"
@@ -12,72 +11,128 @@ import (
 )
 
 type settings struct {
-	Enabled     bool          `json:"enabled"`
-	Host        string        `json:"host"`
-	User        string        `json:"user"`
-	Pass        string        `json:"pass"`
-	Interval    util.Duration `json:"interval"`
-	Threshold   int           `json:"threshold"`
-	DropboxAPI  string        `json:"dropboxApi"`
-	DropboxBase string        `json:"dropboxBase"`
-	DiskBase    string        `json:"diskBase"`
-	DiskForce   bool          `json:"diskForce"`
+	Host        string        `json:"host" help:"camera address, as host or host:port"`
+	User        string        `json:"user" help:"camera username"`
+	Pass        string        `json:"pass" help:"camera password" secret:"true"`
+	Interval    util.Duration `json:"interval" help:"time between snaps (minimum 100ms)" default:"100ms"`
+	Threshold   int           `json:"threshold" help:"pixel difference required to store a pair of snaps" default:"4000"`
+	DropboxAPI  string        `json:"dropboxApi" help:"dropbox access token, enables uploads" secret:"true"`
+	DropboxBase string        `json:"dropboxBase" help:"dropbox directory to upload into" default:"/"`
+	DiskBase    string        `json:"diskBase" help:"existing directory to store snaps into, enables disk storage"`
+	DiskForce   bool          `json:"diskForce" help:"store snaps on disk even when uploaded to dropbox"`
 }
 
 func (w *Webcam) Get() interface{} {
 	return &w.settings
 }
 
-func (w *Webcam) Set(j json.RawMessage) error {
+func (w *Webcam) Migrations() []func(map[string]interface{}) error {
+	return []func(map[string]interface{}) error{
+		//v1: durations as strings, enabled moved to the module container
+		func(s map[string]interface{}) error {
+			util.MigrateSeconds(s, "interval")
+			delete(s, "enabled")
+			return nil
+		},
+	}
+}
+
+//Validate checks j without applying it
+func (w *Webcam) Validate(j json.RawMessage) error {
+	_, _, err := w.parse(j)
+	return err
+}
+
+//parse decodes j over a copy of the current settings,
+//returning them along with the camera origin
+func (w *Webcam) parse(j json.RawMessage) (settings, string, error) {
+	s, _ := w.config()
 	if j != nil {
-		if err := json.Unmarshal(j, &w.settings); err != nil {
-			return err
+		if err := json.Unmarshal(j, &s); err != nil {
+			return s, "", err
 		}
 	}
-	if w.settings.Interval.D() < 100*time.Millisecond {
-		w.settings.Interval = util.Duration(100 * time.Millisecond)
+	if s.Interval.D() < 100*time.Millisecond {
+		s.Interval = util.Duration(100 * time.Millisecond)
+	}
+	origin, err := s.validate()
+	if err != nil {
+		return s, "", err
+	}
+	if s.DropboxBase == "" {
+		s.DropboxBase = "/"
 	}
-	ready := false
-	if w.settings.Host != "" {
-		origin := "http://" + w.settings.Host + "/"
+	return s, origin, nil
+}
+
+//validate checks the host and disk base, returning the camera origin
+func (s *settings) validate() (string, error) {
+	origin := ""
+	if s.Host != "" {
+		origin = "http://" + s.Host + "/"
 		if _, err := url.Parse(origin); err != nil {
-			return errors.New("Invalid host")
+			return "", errors.New("Invalid host")
 		}
-		w.origin = origin
-		ready = true
-	}
-	if !ready {
-		w.settings.Enabled = false
 	}
 	//validate disk
-	if base := w.settings.DiskBase; base != "" {
-		s, err := os.Stat(base)
+	if base := s.DiskBase; base != "" {
+		info, err := os.Stat(base)
 		if err != nil {
-			return errors.New("Invalid disk base")
-		} else if !s.IsDir() {
-			return errors.New("Invalid disk base dir")
+			return "", errors.New("Invalid disk base")
+		} else if !info.IsDir() {
+			return "", errors.New("Invalid disk base dir")
 		}
 	}
-	//validate dropbox
-	if w.settings.DropboxBase == "" {
-		w.settings.DropboxBase = "/"
+	return origin, nil
+}
+
+func (w *Webcam) Set(j json.RawMessage) error {
+	//rejected settings leave the current ones untouched
+	s, origin, err := w.parse(j)
+	if err != nil {
+		return err
 	}
-	//close last one
-	if w.dropcam != nil {
-		w.dropcam.close()
-		w.dropcam = nil
+	//while running, log into dropbox before applying,
+	//otherwise Start logs in
+	w.mut.Lock()
+	running := w.running
+	w.mut.Unlock()
+	var dc *dropcam
+	if running {
+		if dc, err = w.openDropcam(s); err != nil {
+			return err
+		}
 	}
-	//initialise dropbox
-	if api := w.settings.DropboxAPI; api != "" {
-		base := w.settings.DropboxBase
-		dc, err := newDropcam(api, base)
-		if err != nil {
-			log.Printf("[webcam] dropbox login failed: %s", err)
-			return errors.New("Dropbox login failed")
+	w.mut.Lock()
+	w.settings = s
+	w.origin = origin
+	//replace the last one, unless stopped meanwhile
+	last := w.dropcam
+	w.dropcam = nil
+	if w.running {
+		w.dropcam, dc = dc, nil
+	}
+	w.mut.Unlock()
+	for _, c := range []*dropcam{last, dc} {
+		if c != nil {
+			c.close()
 		}
-		w.dropcam = dc
 	}
 	//do check now!
 	w.timer.Reset(0)
 	return nil
 }
+
+//openDropcam logs into dropbox using the settings s,
+//returning nil when uploads are not configured
+func (w *Webcam) openDropcam(s settings) (*dropcam, error) {
+	if s.DropboxAPI == "" {
+		return nil, nil
+	}
+	dc, err := newDropcam(s.DropboxAPI, s.DropboxBase, w.log, w.metrics.queue)
+	if err != nil {
+		w.log.Error("dropbox login failed", "err", err)
+		return nil, errors.New("Dropbox login failed")
+	}
+	return dc, nil
+}
@@ -3,14 +3,15 @@ package webcam
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -19,6 +20,10 @@ import (
 
 	"github.com/boltdb/bolt"
 	"github.com/jpillora/backoff"
+	"github.com/jpillora/castlebot/castle/events"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/metrics"
+	"github.com/jpillora/castlebot/castle/util"
 )
 
 func New(db *bolt.DB) *Webcam {
@@ -27,102 +32,305 @@ func New(db *bolt.DB) *Webcam {
 	w.timer = time.NewTimer(time.Duration(0))
 	w.timer.Stop()
 	w.snaps = []*snap{}
-	w.settings.Enabled = false
 	w.settings.Interval = 1
 	w.settings.Threshold = 4000
-	go w.check()
 	return w
 }
 
 type Webcam struct {
-	db        *bolt.DB
+	db     *bolt.DB
+	worker util.Worker
+	//mut guards running, settings, origin,
+	//dropcam, snaps, status and pushedAt
+	mut       sync.Mutex
+	running   bool
+	storing   sync.WaitGroup
 	timer     *time.Timer
 	snaps     []*snap
 	computing uint32
+	fetchedAt int64
 	computed  *snap
 	origin    string
 	dropcam   *dropcam
-	settings  settings
+	bus       *events.Bus
+	log       *logs.Logger
+	metrics   struct {
+		latency  *metrics.Histogram
+		failures *metrics.Counter
+		diff     *metrics.Gauge
+		queue    *metrics.Gauge
+	}
+	settings settings
+	updates  chan interface{}
+	pushedAt time.Time
+	status   struct {
+		Diff     int        `json:"diff"`
+		Motions  int        `json:"motions"`
+		MotionAt *time.Time `json:"motionAt,omitempty"`
+	}
 }
 
 func (w *Webcam) ID() string {
 	return "webcam"
 }
 
-func (w *Webcam) check() {
+func (w *Webcam) EnabledByDefault() bool {
+	return false
+}
+
+func (w *Webcam) SetLogger(l *logs.Logger) {
+	w.log = l
+}
+
+func (w *Webcam) SetBus(bus *events.Bus) {
+	w.bus = bus
+}
+
+func (w *Webcam) Status(updates chan interface{}) {
+	w.mut.Lock()
+	w.updates = updates
+	w.mut.Unlock()
+	w.push()
+}
+
+//push sends a copy of the status
+func (w *Webcam) push() {
+	w.mut.Lock()
+	updates := w.updates
+	status := w.status
+	w.pushedAt = time.Now()
+	w.mut.Unlock()
+	if updates != nil {
+		updates <- &status
+	}
+}
+
+//config returns a copy of the settings and camera origin
+func (w *Webcam) config() (settings, string) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	return w.settings, w.origin
+}
+
+func (w *Webcam) SetMetrics(reg *metrics.Registry) {
+	w.metrics.latency = reg.Histogram("castle_webcam_snap_duration_seconds", "Time taken to fetch snaps", []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5})
+	w.metrics.failures = reg.Counter("castle_webcam_snap_failures_total", "Failed snap fetches")
+	w.metrics.diff = reg.Gauge("castle_webcam_diff_score", "Difference between the last two computed snaps")
+	w.metrics.queue = reg.Gauge("castle_webcam_dropbox_queue", "Snaps waiting to be uploaded to dropbox")
+}
+
+func (w *Webcam) Start() error {
+	//open the dropbox uploader, a failed login
+	//is logged and snaps go to disk only
+	s, _ := w.config()
+	dc, _ := w.openDropcam(s)
+	w.mut.Lock()
+	w.running = true
+	w.dropcam = dc
+	w.mut.Unlock()
+	atomic.StoreInt64(&w.fetchedAt, time.Now().UnixNano())
+	w.worker.Start(w.check)
+	return nil
+}
+
+func (w *Webcam) Stop() error {
+	//no more snapshots may begin storing
+	w.mut.Lock()
+	w.running = false
+	w.mut.Unlock()
+	w.worker.Stop()
+	//let in-progress writes complete
+	w.storing.Wait()
+	//release dropbox uploader once its queue is empty
+	w.mut.Lock()
+	dc := w.dropcam
+	w.dropcam = nil
+	w.mut.Unlock()
+	if dc != nil {
+		dc.close()
+		dc.wait()
+	}
+	return nil
+}
+
+func (w *Webcam) check(done <-chan struct{}) {
 	b := backoff.Backoff{Max: 5 * time.Minute}
 	for {
 		//take snap, process, store, etc
 		t0 := time.Now()
+		wait := time.Duration(0)
 		if err := w.snap(); err != nil {
-			log.Printf("[webcam] snap failed: %s", err)
-			time.Sleep(b.Duration())
+			w.log.Error("snap failed", "err", err)
+			wait = b.Duration()
 		} else {
 			b.Reset()
 		}
 		dur := time.Now().Sub(t0)
-		interval := time.Duration(w.settings.Interval) - dur
+		s, _ := w.config()
+		interval := s.Interval.D() - dur
 		if interval < 0 {
 			interval = 0
 		}
 		//set timer to
-		w.timer.Reset(interval)
+		w.timer.Reset(interval + wait)
 		//wait for timer
-		<-w.timer.C
+		select {
+		case <-w.timer.C:
+		case <-done:
+			w.timer.Stop()
+			return
+		}
 	}
 }
 
 func (w *Webcam) snap() error {
-	//disabled
-	if !w.settings.Enabled || w.origin == "" {
+	//no camera
+	if _, origin := w.config(); origin == "" {
 		return nil
 	}
-	//build url
-	q := url.Values{}
-	q.Set("user", w.settings.User)
-	q.Set("pwd", w.settings.Pass)
-	snapshotURL := w.origin + "/snapshot.cgi?" + q.Encode()
-	//
-	resp, err := http.Get(snapshotURL)
-	if err != nil {
-		return fmt.Errorf("request: %s", err)
-	}
-	defer resp.Body.Close()
-	b, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("download: %s", err)
-	}
-	//create snap
-	curr, err := newSnap(b)
+	curr, err := w.fetch()
 	if err != nil {
 		return err
 	}
 	//store last 100 snaps
+	w.mut.Lock()
 	if len(w.snaps) == 100 {
 		w.snaps = append(w.snaps[1:], curr)
 	} else {
 		w.snaps = append(w.snaps, curr)
 	}
-	//attempt to mark compute in progress
+	w.mut.Unlock()
+	//attempt to mark compute in progress, Stop
+	//waits for it since it may store snaps
 	if atomic.CompareAndSwapUint32(&w.computing, 0, 1) {
+		w.storing.Add(1)
 		go w.computeDiff(curr)
 	}
 	return nil
 }
 
+//Health fails once snaps have not been fetched for ten intervals
+//(at least a minute), or the dropbox queue is nearly full
+func (w *Webcam) Health() error {
+	s, origin := w.config()
+	if origin == "" {
+		return nil
+	}
+	limit := 10 * s.Interval.D()
+	if limit < time.Minute {
+		limit = time.Minute
+	}
+	since := time.Since(time.Unix(0, atomic.LoadInt64(&w.fetchedAt)))
+	if since > limit {
+		return fmt.Errorf("last snap fetched %s ago", since.Round(time.Second))
+	}
+	w.mut.Lock()
+	dc := w.dropcam
+	w.mut.Unlock()
+	if dc != nil {
+		if n := len(dc.queue); n >= queueSize*9/10 {
+			return fmt.Errorf("dropbox queue saturated (%d/%d)", n, queueSize)
+		}
+	}
+	return nil
+}
+
+//Snapshot takes a snap and stores it regardless of motion
+func (w *Webcam) Snapshot() (string, error) {
+	//begin storing under mut, so Stop waits for it
+	w.mut.Lock()
+	if !w.running {
+		w.mut.Unlock()
+		return "", errors.New("webcam not active")
+	}
+	if w.origin == "" {
+		w.mut.Unlock()
+		return "", errors.New("webcam not configured")
+	}
+	w.storing.Add(1)
+	w.mut.Unlock()
+	s, err := w.fetch()
+	if err != nil {
+		w.storing.Done()
+		return "", err
+	}
+	w.store(s)
+	return string(s.id), nil
+}
+
+//fetch downloads a snap from the camera
+func (w *Webcam) fetch() (*snap, error) {
+	t0 := time.Now()
+	s, err := w.download()
+	if err != nil {
+		w.metrics.failures.Inc()
+		return nil, err
+	}
+	w.metrics.latency.Observe(time.Since(t0).Seconds())
+	atomic.StoreInt64(&w.fetchedAt, time.Now().UnixNano())
+	return s, nil
+}
+
+func (w *Webcam) download() (*snap, error) {
+	s, origin := w.config()
+	//build url
+	q := url.Values{}
+	q.Set("user", s.User)
+	q.Set("pwd", s.Pass)
+	snapshotURL := origin + "/snapshot.cgi?" + q.Encode()
+	//
+	resp, err := http.Get(snapshotURL)
+	if err != nil {
+		return nil, fmt.Errorf("request: %s", err)
+	}
+	defer resp.Body.Close()
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("download: %s", err)
+	}
+	//create snap
+	return newSnap(b)
+}
+
 func (w *Webcam) computeDiff(curr *snap) {
+	defer w.storing.Done()
 	//has previosu?
 	if w.computed != nil {
 		//compare with last computed
-		diff := curr.computeDiff(w.settings.Threshold, w.computed)
+		s, _ := w.config()
+		diff := curr.computeDiff(s.Threshold, w.computed)
+		w.metrics.diff.Set(float64(diff))
+		motion := diff > s.Threshold
+		w.mut.Lock()
+		w.status.Diff = diff
+		if motion {
+			w.status.Motions++
+			t := curr.t
+			w.status.MotionAt = &t
+		}
+		w.mut.Unlock()
 		// log.Printf("compute: %s -> %s: %d", w.computed.id, curr.id, diff)
-		if diff > w.settings.Threshold {
+		if motion {
 			//compare last to current, if changed much, store both
+			w.storing.Add(2)
 			go w.store(w.computed)
 			go w.store(curr)
+			w.bus.Publish(events.Event{
+				Module: w.ID(),
+				Type:   events.Motion,
+				Data:   events.MotionData{Snap: string(curr.id), Diff: diff},
+			})
 		}
 	}
 	w.computed = curr
+	//diffs are computed many times a second,
+	//push at most once a second
+	w.mut.Lock()
+	due := time.Since(w.pushedAt) >= time.Second
+	w.mut.Unlock()
+	if due {
+		w.push()
+	}
 	//mark complete
 	atomic.StoreUint32(&w.computing, 0)
 }
@@ -130,37 +338,47 @@ func (w *Webcam) computeDiff(curr *snap) {
 var bucketName = []byte("snaps")
 
 func (w *Webcam) store(s *snap) {
+	defer w.storing.Done()
 	if s.stored {
 		return
 	}
 	s.stored = true
+	settings, _ := w.config()
+	w.mut.Lock()
+	dc := w.dropcam
+	w.mut.Unlock()
 	//store to dropbox
-	enqueued := w.dropcam != nil && w.dropcam.enque(s)
+	enqueued := dc != nil && dc.enque(s)
 	//store to disk?
-	disk := w.settings.DiskBase != "" && (!enqueued || w.settings.DiskForce)
+	disk := settings.DiskBase != "" && (!enqueued || settings.DiskForce)
 	if disk {
-		dir := dateDir(w.settings.DiskBase, s.t)
+		dir := dateDir(settings.DiskBase, s.t)
 		if s, err := os.Stat(dir); os.IsNotExist(err) {
 			if err := os.Mkdir(dir, 0755); err != nil {
-				log.Printf("mkdir dir failed: %s", err)
+				w.log.Error("mkdir dir failed", "dir", dir, "err", err)
 				return
 			}
 		} else if err != nil {
-			log.Printf("stat dir failed: %s", err)
+			w.log.Error("stat dir failed", "dir", dir, "err", err)
 			return
 		} else if !s.IsDir() {
-			log.Printf("expected date dir")
+			w.log.Error("expected date dir", "dir", dir)
 			return
 		}
-		//write image into dir
+		//write image into dir, via a temp file
+		//so partial writes are never left behind
 		filepath := timeJpg(dir, s.t)
-		if err := ioutil.WriteFile(filepath, s.raw, 0755); err != nil {
-			log.Printf("write jpg failed: %s", err)
+		if err := ioutil.WriteFile(filepath+".tmp", s.raw, 0755); err != nil {
+			w.log.Error("write jpg failed", "file", filepath, "err", err)
+			return
+		}
+		if err := os.Rename(filepath+".tmp", filepath); err != nil {
+			w.log.Error("rename jpg failed", "file", filepath, "err", err)
 			return
 		}
 	}
 	//stored!
-	log.Printf("[webcam] wrote snap %s (diff: %d)", s.id, s.pdiffNum)
+	w.log.Debug("wrote snap", "id", s.id, "diff", s.pdiffNum)
 }
 
 func (wc *Webcam) RegisterRoutes(mux *goji.Mux) {
@@ -173,7 +391,8 @@ func (wc *Webcam) RegisterRoutes(mux *goji.Mux) {
 }
 
 func (wc *Webcam) getSnap(w http.ResponseWriter, r *http.Request) {
-	base := wc.settings.DiskBase
+	settings, _ := wc.config()
+	base := settings.DiskBase
 	if base == "" {
 		w.WriteHeader(404)
 		w.Write([]byte("disk disabled"))
@@ -225,14 +444,18 @@ func (wc *Webcam) getLive(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "index must be an integer", http.StatusBadRequest)
 		return
 	}
+	wc.mut.Lock()
 	total := len(wc.snaps)
-	if total == 0 || i < 0 || i > total {
+	if total == 0 || i < 0 || i >= total {
+		wc.mut.Unlock()
 		http.Error(w, "index out of range: "+istr, http.StatusBadRequest)
 		return
 	}
 	//reverse index order
 	s := wc.snaps[total-1-i]
-	intervalMs := wc.settings.Interval / 1e6
+	wc.mut.Unlock()
+	settings, _ := wc.config()
+	intervalMs := settings.Interval / 1e6
 	w.Header().Set("Interval-Millis", strconv.Itoa(int(intervalMs)))
 	//find image
 	var b []byte
@@ -263,13 +486,14 @@ func (wc *Webcam) move(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "invalid dir", 400)
 		return
 	}
+	settings, origin := wc.config()
 	//build url
 	q := url.Values{}
-	q.Set("loginuse", wc.settings.User)
-	q.Set("loginpas", wc.settings.Pass)
+	q.Set("loginuse", settings.User)
+	q.Set("loginpas", settings.Pass)
 	q.Set("command", cmd)
 	q.Set("onestep", "1")
-	decoderURL := wc.origin + "/decoder_control.cgi?" + q.Encode()
+	decoderURL := origin + "/decoder_control.cgi?" + q.Encode()
 	resp, err := http.Get(decoderURL)
 	if err != nil {
 		http.Error(w, "req invalid", 400)
@@ -280,7 +504,7 @@ func (wc *Webcam) move(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	w.Write([]byte("success"))
-	log.Printf("[webcam] move: %s", dir)
+	wc.log.Info("moved", "dir", dir)
 }
 
 func toID(t time.Time) []byte {
@@ -0,0 +1,147 @@
+package series
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+//Point is a single value in a series, downsampled
+//points hold the average, min and max of their step
+type Point struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+	Min   *float64  `json:"min,omitempty"`
+	Max   *float64  `json:"max,omitempty"`
+}
+
+//Series is the result of a query
+type Series struct {
+	Module     string  `json:"module"`
+	Field      string  `json:"field"`
+	Range      string  `json:"range"`
+	Resolution string  `json:"resolution"`
+	Points     []Point `json:"points"`
+}
+
+//defaultRange is queried when no range is given
+const defaultRange = time.Hour
+
+//pick finds the finest resolution retaining all of rng
+func pick(rng time.Duration) (resolution, error) {
+	for _, r := range resolutions {
+		if rng <= r.retention {
+			return r, nil
+		}
+	}
+	last := resolutions[len(resolutions)-1]
+	return last, fmt.Errorf("range must be at most %s", last.retention)
+}
+
+//Query reads the points of the field of module over the last rng
+func (s *Store) Query(module, field string, rng time.Duration) (*Series, error) {
+	r, err := pick(rng)
+	if err != nil {
+		return nil, err
+	}
+	series := &Series{
+		Module:     module,
+		Field:      field,
+		Range:      rng.String(),
+		Resolution: r.name,
+		Points:     []Point{},
+	}
+	from := timeKey(time.Now().Add(-rng))
+	err = s.db.View(func(tx *bolt.Tx) error {
+		b := nested(tx, bucketName, []byte(module), []byte(field), []byte(r.name))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+		for k, v := c.Seek(from); k != nil; k, v = c.Next() {
+			p := decodePoint(v)
+			if p.Count == 0 {
+				continue
+			}
+			pt := Point{Time: keyTime(k), Value: p.Sum / float64(p.Count)}
+			if r.name != "raw" {
+				min, max := p.Min, p.Max
+				pt.Min, pt.Max = &min, &max
+			}
+			series.Points = append(series.Points, pt)
+		}
+		return nil
+	})
+	return series, err
+}
+
+//Fields lists the recorded fields of module
+func (s *Store) Fields(module string) ([]string, error) {
+	fields := []string{}
+	err := s.db.View(func(tx *bolt.Tx) error {
+		b := nested(tx, bucketName, []byte(module))
+		if b == nil {
+			return nil
+		}
+		return eachBucket(b, func(name []byte, _ *bolt.Bucket) error {
+			fields = append(fields, string(name))
+			return nil
+		})
+	})
+	sort.Strings(fields)
+	return fields, err
+}
+
+//nested finds the bucket at path, or nil
+func nested(tx *bolt.Tx, path ...[]byte) *bolt.Bucket {
+	b := tx.Bucket(path[0])
+	for _, name := range path[1:] {
+		if b == nil {
+			return nil
+		}
+		b = b.Bucket(name)
+	}
+	return b
+}
+
+//Handler serves the points of module's ?field= over the last ?range=
+//(a duration, defaulting to 1h), or lists its fields when no field is given
+func (s *Store) Handler(module string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var v interface{}
+		var err error
+		if field := q.Get("field"); field == "" {
+			fields, ferr := s.Fields(module)
+			v, err = map[string][]string{"fields": fields}, ferr
+		} else {
+			rng := defaultRange
+			if str := q.Get("range"); str != "" {
+				if rng, err = time.ParseDuration(str); err != nil || rng <= 0 {
+					http.Error(w, "invalid range: "+str, http.StatusBadRequest)
+					return
+				}
+			}
+			if _, err := pick(rng); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			v, err = s.Query(module, field, rng)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(b)
+	}
+}
@@ -0,0 +1,269 @@
+package series
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/logs"
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+var bucketName = []byte("status_history")
+
+//resolution is a level of downsampling, points are
+//kept at each resolution until older than retention
+type resolution struct {
+	name      string
+	step      time.Duration
+	retention time.Duration
+}
+
+var resolutions = []resolution{
+	{"raw", time.Second, time.Hour},
+	{"1m", time.Minute, 24 * time.Hour},
+	{"1h", time.Hour, 30 * 24 * time.Hour},
+}
+
+const (
+	//flushInterval is the time samples are
+	//buffered before being written together
+	flushInterval = 10 * time.Second
+	pruneInterval = 10 * time.Minute
+	//maxBuffered samples are held while writes fail
+	maxBuffered = 10000
+)
+
+type sample struct {
+	module, field string
+	time          time.Time
+	value         float64
+}
+
+//Store records the numeric fields of module statuses,
+//downsampling them as they age
+type Store struct {
+	db     *bolt.DB
+	log    *logs.Logger
+	worker util.Worker
+	mut    sync.Mutex
+	buffer []sample
+}
+
+func New(db *bolt.DB) *Store {
+	return &Store{db: db, log: logs.New("series")}
+}
+
+//Record buffers each top-level numeric field of status
+func (s *Store) Record(module string, status interface{}) {
+	b, err := json.Marshal(status)
+	if err != nil {
+		return
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return
+	}
+	now := time.Now()
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for field, v := range fields {
+		if n, ok := v.(float64); ok {
+			s.buffer = append(s.buffer, sample{module, field, now, n})
+		}
+	}
+	if over := len(s.buffer) - maxBuffered; over > 0 {
+		s.buffer = s.buffer[over:]
+	}
+}
+
+func (s *Store) Start() {
+	s.worker.Start(s.run)
+}
+
+//Stop writes any buffered samples
+func (s *Store) Stop() {
+	s.worker.Stop()
+}
+
+func (s *Store) run(done <-chan struct{}) {
+	flush := time.NewTicker(flushInterval)
+	defer flush.Stop()
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+	s.prune()
+	for {
+		select {
+		case <-flush.C:
+			s.flush()
+		case <-prune.C:
+			s.prune()
+		case <-done:
+			s.flush()
+			return
+		}
+	}
+}
+
+//flush writes the buffered samples at every resolution
+func (s *Store) flush() {
+	s.mut.Lock()
+	samples := s.buffer
+	s.buffer = nil
+	s.mut.Unlock()
+	if len(samples) == 0 {
+		return
+	}
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		for _, smp := range samples {
+			for _, r := range resolutions {
+				b, err := fieldBucket(tx, smp.module, smp.field, r.name)
+				if err != nil {
+					return err
+				}
+				k := timeKey(smp.time.Truncate(r.step))
+				p := point{Count: 1, Sum: smp.value, Min: smp.value, Max: smp.value}
+				//raw points keep the last value each second,
+				//downsampled points accumulate
+				if r.name != "raw" {
+					if v := b.Get(k); v != nil {
+						p.merge(decodePoint(v))
+					}
+				}
+				if err := b.Put(k, p.encode()); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.Error("failed to write samples", "count", len(samples), "err", err)
+		//retry with the next flush
+		s.mut.Lock()
+		s.buffer = append(samples, s.buffer...)
+		if over := len(s.buffer) - maxBuffered; over > 0 {
+			s.buffer = s.buffer[over:]
+		}
+		s.mut.Unlock()
+	}
+}
+
+//prune deletes points older than their resolution's retention
+func (s *Store) prune() {
+	now := time.Now()
+	n := 0
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		root := tx.Bucket(bucketName)
+		if root == nil {
+			return nil
+		}
+		return eachBucket(root, func(module []byte, mb *bolt.Bucket) error {
+			return eachBucket(mb, func(field []byte, fb *bolt.Bucket) error {
+				for _, r := range resolutions {
+					b := fb.Bucket([]byte(r.name))
+					if b == nil {
+						continue
+					}
+					cutoff := timeKey(now.Add(-r.retention))
+					//collect before deleting, deleting
+					//while iterating skips entries
+					old := [][]byte{}
+					c := b.Cursor()
+					for k, _ := c.First(); k != nil && bytes.Compare(k, cutoff) < 0; k, _ = c.Next() {
+						old = append(old, append([]byte{}, k...))
+					}
+					for _, k := range old {
+						if err := b.Delete(k); err != nil {
+							return err
+						}
+					}
+					n += len(old)
+				}
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		s.log.Error("failed to prune", "err", err)
+	} else if n > 0 {
+		s.log.Debug("pruned points", "count", n)
+	}
+}
+
+//eachBucket calls fn with each nested bucket of b
+func eachBucket(b *bolt.Bucket, fn func(name []byte, nested *bolt.Bucket) error) error {
+	return b.ForEach(func(k, v []byte) error {
+		if v != nil {
+			return nil
+		}
+		return fn(k, b.Bucket(k))
+	})
+}
+
+//fieldBucket finds or creates the bucket of points
+//of a module field at a resolution
+func fieldBucket(tx *bolt.Tx, module, field, res string) (*bolt.Bucket, error) {
+	b, err := tx.CreateBucketIfNotExists(bucketName)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range []string{module, field, res} {
+		if b, err = b.CreateBucketIfNotExists([]byte(name)); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+func timeKey(t time.Time) []byte {
+	k := make([]byte, 8)
+	binary.BigEndian.PutUint64(k, uint64(t.Unix()))
+	return k
+}
+
+func keyTime(k []byte) time.Time {
+	return time.Unix(int64(binary.BigEndian.Uint64(k)), 0)
+}
+
+//point is the aggregate of the samples in a step
+type point struct {
+	Count    uint64
+	Sum      float64
+	Min, Max float64
+}
+
+func (p *point) merge(o point) {
+	if o.Count == 0 {
+		return
+	}
+	p.Count += o.Count
+	p.Sum += o.Sum
+	p.Min = math.Min(p.Min, o.Min)
+	p.Max = math.Max(p.Max, o.Max)
+}
+
+func (p point) encode() []byte {
+	b := make([]byte, 32)
+	binary.BigEndian.PutUint64(b, p.Count)
+	binary.BigEndian.PutUint64(b[8:], math.Float64bits(p.Sum))
+	binary.BigEndian.PutUint64(b[16:], math.Float64bits(p.Min))
+	binary.BigEndian.PutUint64(b[24:], math.Float64bits(p.Max))
+	return b
+}
+
+func decodePoint(b []byte) point {
+	if len(b) != 32 {
+		return point{}
+	}
+	return point{
+		Count: binary.BigEndian.Uint64(b),
+		Sum:   math.Float64frombits(binary.BigEndian.Uint64(b[8:])),
+		Min:   math.Float64frombits(binary.BigEndian.Uint64(b[16:])),
+		Max:   math.Float64frombits(binary.BigEndian.Uint64(b[24:])),
+	}
+}
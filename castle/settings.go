@@ -0,0 +1,124 @@
+package castle
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/jpillora/castlebot/castle/modules"
+	"github.com/jpillora/castlebot/castle/modules/auth"
+	"github.com/jpillora/castlebot/castle/util"
+)
+
+const settingsUsage = `
+  Usage: castle settings [--db <path>] <command> [module] [json]
+
+  Manages the settings database while castle is stopped
+
+  Commands:
+  list                 list modules with stored settings
+  get <module>         print the stored settings of module
+  set <module> [json]  merge json (or stdin) into the stored settings of module
+  reset <module>       remove the stored settings, enabled state, log level
+                       and settings history of module, restoring defaults
+
+`
+
+//Commands are the offline subcommands, run while the bot is stopped.
+//Each receives the default database location and its arguments.
+var Commands = map[string]func(db string, args []string) error{
+	"settings": SettingsCommand,
+	"compact":  CompactCommand,
+}
+
+//SettingsCommand runs the "castle settings" subcommand
+func SettingsCommand(db string, args []string) error {
+	flags := flag.NewFlagSet("settings", flag.ContinueOnError)
+	flags.StringVar(&db, "db", db, "castle settings database location")
+	flags.Usage = func() {
+		fmt.Fprint(os.Stderr, settingsUsage)
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+	if len(args) == 0 {
+		flags.Usage()
+		return errors.New("command required")
+	}
+	cmd, args := args[0], args[1:]
+	id := ""
+	if cmd != "list" {
+		if len(args) == 0 {
+			return fmt.Errorf("%s: module required", cmd)
+		}
+		id, args = args[0], args[1:]
+	}
+	//the bot holds the database lock while running
+	bdb, err := bolt.Open(db, 0600, &bolt.Options{Timeout: time.Second})
+	if err == bolt.ErrTimeout {
+		return errors.New("database is locked, castle must be stopped first")
+	} else if err != nil {
+		return err
+	}
+	defer bdb.Close()
+	m := modules.New(bdb, nil, nil, nil, nil)
+	switch cmd {
+	case "list":
+		ids, err := m.StoredIDs()
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			enabled := m.StoredEnabled(id)
+			if enabled == "" {
+				enabled = "default"
+			}
+			fmt.Printf("%-12s enabled: %s\n", id, enabled)
+		}
+	case "get":
+		b := m.StoredSettings(id)
+		if len(b) == 0 {
+			return fmt.Errorf("no stored settings: %s", id)
+		}
+		out := bytes.Buffer{}
+		json.Indent(&out, b, "", "  ")
+		fmt.Println(out.String())
+	case "set":
+		var patch []byte
+		if len(args) > 0 && args[0] != "-" {
+			patch = []byte(args[0])
+		} else if patch, err = ioutil.ReadAll(os.Stdin); err != nil {
+			return err
+		}
+		merged, err := util.MergePatch(m.StoredSettings(id), patch)
+		if err != nil {
+			return fmt.Errorf("invalid json: %s", err)
+		}
+		//passwords are only stored hashed
+		if id == "auth" {
+			if merged, err = auth.HashSettings(merged); err != nil {
+				return err
+			}
+		}
+		if err := m.StoreSettings(id, merged, "cli"); err != nil {
+			return err
+		}
+		fmt.Printf("updated settings: %s: %s\n", id, merged)
+	case "reset":
+		if err := m.DeleteSettings(id); err != nil {
+			return err
+		}
+		fmt.Printf("reset settings: %s\n", id)
+	default:
+		flags.Usage()
+		return fmt.Errorf("unknown command: %s", cmd)
+	}
+	return nil
+}
@@ -15,13 +15,14 @@
 // css/themes/default/assets/fonts/icons.woff (90.412kB)
 // css/themes/default/assets/fonts/icons.woff2 (71.896kB)
 // css/themes/default/assets/images/flags.png (28.123kB)
-// index.html (11.751kB)
+// index.html (18.649kB)
 // js/controller/app.js (450B)
-// js/controller/auth.js (630B)
-// js/controller/cam.js (4.269kB)
+// js/controller/auth.js (4.635kB)
+// js/controller/cam.js (4.567kB)
 // js/controller/gpio.js (648B)
+// js/controller/logs.js (1.556kB)
 // js/controller/machine.js (496B)
-// js/controller/scanner.js (2.178kB)
+// js/controller/scanner.js (2.446kB)
 // js/directives.js (6.094kB)
 // js/init.js (146B)
 // js/services.js (1.314kB)
@@ -396,7 +397,7 @@ func cssThemesDefaultAssetsImagesFlagsPng() (*asset, error) {
 	return a, nil
 }
 
-var _indexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbc\x5a\x5b\x6f\xe3\xba\x11\x7e\x76\x7e\x05\x23\xf4\xc0\x7b\x80\xd8\x72\xde\x8a\x54\xf2\x22\xc9\x66\xf7\x04\x68\x2e\xc8\x65\xdb\xd3\xa2\x0f\xb4\x34\x96\xb8\x91\x48\x95\xa4\xec\x04\x5e\xff\xf7\x82\xa4\x24\x53\x17\xdb\xca\x65\x1b\x04\xb0\x48\x0d\x87\x1f\x67\x86\x33\xc3\xa1\xbc\xc3\x2f\x37\xe7\x0f\x7f\xde\x5e\xa0\x58\xa6\xc9\xf4\xc0\x53\x3f\x88\x46\x23\x9c\x65\xbe\x13\x60\x21\x13\x70\x54\x3b\x60\x54\x72\x96\x24\xc0\x7d\xe7\x34\xcb\xce\xab\x26\xc2\x02\xe1\x2c\x73\xa6\x07\x07\x5e\x0c\x38\x9c\x1e\x0c\xbc\x14\x24\x46\x41\x8c\xb9\x00\xe9\x3b\xb9\x9c\x8f\xfe\xea\x54\xfd\xb1\x94\xd9\x08\xfe\x9b\x93\x85\xef\xfc\x73\xf4\x78\x3a\x3a\x67\x69\x86\x25\x99\xa9\x99\xd4\x34\x40\xa5\xef\x5c\x5e\xf8\x10\x46\x70\x14\xc4\x9c\xa5\xe0\x1f\x3b\xc8\xad\x38\x50\x9c\x82\xef\x2c\x08\x2c\x33\xc6\xa5\x35\x68\x49\x42\x19\xfb\x21\x2c\x48\x00\x23\xdd\x38\x42\x84\x12\x49\x70\x32\x12\x01\x4e\xc0\x3f\x1e\x4f\x8e\x50\x8a\x9f\x49\x9a\xa7\x9b\x2e\x0d\x2e\x21\xf4\x09\x71\x48\x7c\x87\x04\x8c\x3a\x48\xbe\x64\xe0\x3b\x24\xc5\x11\xb8\xcf\x23\xd3\x17\x73\x98\xfb\x4e\x20\x84\x6b\x44\x33\xce\x68\xd4\x18\x2c\xe4\x4b\x02\x22\x06\x90\x36\xb9\x80\x14\x53\x49\x82\x71\x4a\xe8\x38\x10\xa2\xd7\x20\x9c\x65\x15\xad\x24\x32\x01\xa5\x88\x19\xa1\xa1\xef\x84\x58\xe2\xb1\x92\x03\xfa\xf9\x13\x0d\xcf\x35\x98\x19\x93\x43\x67\x5a\x3d\x7b\xae\x1e\xa3\x06\x6b\xf6\xd3\x83\xc1\x60\xc6\xc2\x17\xb4\x3a\x18\x0c\x06\x2c\xc3\x01\x91\x2f\x27\x68\x72\x30\x18\xac\x0f\x8a\x77\xe3\x84\xe1\x10\xc2\x06\xc9\xb1\x26\x19\x78\x6e\xc1\xc7\x73\x8d\xa2\x0f\x3c\xcd\x4f\x59\x47\x82\x85\xf0\x9d\x95\x19\x7e\x22\x79\x0e\x6b\x0d\x3b\x24\x0b\x54\xbc\xcc\x09\x4a\x81\xe6\xaa\xbb\xd9\xaf\x14\x88\x09\x05\xae\x5f\x0e\x3c\x5c\x08\x41\x99\x8a\x38\x71\xdd\x88\xc8\x38\x9f\x8d\x03\x96\xba\x3f\x32\x92\x24\x8c\xe3\x42\x01\x33\xa6\xf4\x6f\x18\x29\x50\xc0\x11\x91\x90\x1a\x3e\x03\x8f\x94\xef\xe6\x8c\x4b\x84\x97\x20\x58\x0a\x48\xeb\x72\xea\xb9\xa4\xa0\x12\x19\xa6\xaf\x14\xad\x1a\x62\xb0\xba\xd8\xfc\x1e\x8e\x46\xc8\x70\x2a\xa6\x34\x38\x56\x2b\xb5\x3b\xc6\x9a\xe9\x02\xb8\x20\x8c\xa2\xf5\xba\x60\x80\x46\x23\x33\xd8\x12\x07\x27\x51\x2c\x37\x92\xd2\xd2\xb0\x39\x1a\x71\x93\xe0\xc9\x77\xb4\x7d\x30\x3a\x27\x11\xf2\xd1\xe1\xa6\x55\x0c\xb4\x96\x2f\x40\x4a\x42\x23\x61\x96\x6e\x6b\x6c\x96\xe4\x70\xb2\x19\xba\xb6\xe5\x52\x2c\x6d\xd0\x5a\x16\x42\xb3\x11\x6f\xcf\x92\x28\xe8\x94\xd0\xa8\x35\x4d\x31\x01\x85\x40\x42\x88\x3e\xa3\x61\xc4\x01\xe8\x10\x9d\xa0\x21\x87\x70\x58\x9b\xd4\x12\x6d\x48\x16\xda\x5a\x8a\x87\xea\x77\x97\xf5\x34\x5e\x0a\x89\x83\x27\x3c\x4b\x40\xa9\x01\x45\x9c\x84\x4e\x4b\xe4\x72\x09\xc9\x02\xd0\x92\x84\x80\x02\x96\xe4\x29\x2d\x45\xaf\x94\xea\xeb\x3f\x74\x7f\x7e\x7a\x7d\x7d\x71\x87\xee\x2f\xbe\x5d\x5d\x5c\x3f\x14\xdd\x7e\xa9\xc2\xd6\xbc\x01\xa6\x14\x38\x12\x10\xa5\x40\x65\xcb\x87\xde\x9b\xf7\x75\x3f\x5a\x0c\xaa\x24\x5b\x67\x29\x59\x86\xb0\x94\x38\x88\x21\x44\x09\x9e\x41\x62\x1b\x43\x4e\xc6\x22\x66\x4b\x3a\x2e\x67\xf6\xd1\x61\xb3\xaf\x64\x6c\x34\x3a\x2d\x30\x58\x22\x6f\x2a\x7b\x9e\x30\x2c\x47\xda\x24\xf5\x5c\x64\xee\x3b\x05\xaf\x71\x69\x53\x63\xa0\x4a\xbe\x61\xc5\xdc\x88\x4d\x91\x21\x42\x51\xc6\x59\xc4\x41\x88\x4a\x50\xf6\x8e\xb3\xf9\xe9\x2d\x22\x24\x96\xb9\x30\x78\x89\xf2\xad\xf7\xc5\xd3\x78\x3c\xae\xe3\xdc\xcc\x02\x21\xc2\x12\x71\x50\xc1\x60\xeb\x2c\x87\xbb\xa7\x29\xc7\x0c\x8c\x4c\xc2\xaa\x6d\x98\x08\x42\x03\xd8\x01\x15\xc2\x53\xe9\x20\x1c\xb1\xe9\xa8\x89\xb2\x2e\x5c\xbb\x55\xd9\x77\x43\xd5\x4b\x8e\xb3\x4c\x99\x4e\x42\x42\xa8\x6d\xd6\x90\x2d\xe9\x09\x6a\x6a\x75\xbd\x51\xab\xd4\x96\xbe\x31\x99\x9c\x6e\xec\x5f\xbf\xb3\x94\x24\x8b\x68\x5d\xb5\xf9\xa6\xa1\xde\x4e\xff\x60\x42\x2a\x3f\xe8\xb9\x32\x6e\xbc\xba\xbc\xed\xe8\xbc\x3a\x3d\xef\xe8\xbd\x7b\x78\xe8\xe8\xbd\x07\xa0\x1d\xdd\x5f\x19\xaf\xf7\x7a\xae\x05\x4b\xbd\xb2\x31\x7b\x52\xc5\x9f\xda\x12\x9a\x46\x15\x33\x21\xc5\x38\x01\x1a\xc9\x18\xf9\x3e\x9a\x38\xb5\x19\x43\xb5\xdf\x95\x4a\x7c\xe7\x78\x32\xf9\xcd\x99\x5e\x33\xa4\x87\xa0\x39\xcb\x69\xe8\xb9\x32\xdc\x82\xa5\x9c\x8c\x43\x06\x58\xfa\x4e\xac\x6c\xbd\x36\xab\xad\xb9\x78\xfc\x17\xfd\x54\x6d\xa2\x78\x2c\x00\xe8\xa9\x44\x87\xbe\x8f\x86\x93\xc9\xe4\x78\xa4\xff\x1f\x26\x93\x13\xfd\xff\xaf\xa1\x0a\x3f\xf1\x18\x07\x92\x2c\xc0\x3c\x03\xe7\x8c\x37\x16\xa0\x02\x4c\xac\x27\xac\x42\xd6\x68\xa8\x23\x8c\x0d\x7d\x43\x49\xb2\x1d\x2f\x53\x1c\xec\xe7\xc0\xa5\x44\x9f\x11\xc5\x94\x7d\xd2\x8d\xdf\x95\x17\xdf\x36\x62\xd3\xaa\xef\xa4\x72\xfd\x66\xd3\x34\xf6\x8c\x16\x75\x7f\x4e\x46\x44\x8a\xd7\x9c\xb3\xd4\xe2\xbd\x8f\x6f\xd3\xb8\x6c\x6b\xf2\x5c\xbd\x5f\xf6\x6c\x55\xe5\xe8\xcb\xd0\x3a\xc3\x82\x04\x35\x7f\xaf\x14\xdd\x8e\xca\x03\x6f\xce\x78\x6a\x71\x50\x4d\x6b\x5f\xd6\x02\x13\x43\x73\x02\x49\x28\x2c\xad\xdb\x04\xfa\x65\xcd\x22\x74\x5c\x98\x5e\x52\x09\x7c\x81\x13\xcf\x35\x6d\x8b\x80\xd0\x2c\x97\x45\x72\x2b\xe1\xd9\x20\x4d\x59\xa8\xd3\xd0\xa6\x6b\x27\x05\x1f\x7b\x7a\x4b\x14\x3d\xd1\x9c\x1a\x1b\x7e\x88\x39\x88\x98\x25\xe1\x7b\x51\xc9\x92\xd1\x56\x58\x8d\x96\x85\x31\x00\xb5\x24\xd4\x84\xea\xcd\x72\x29\x19\xb5\xb4\x62\x3a\xec\x08\xbb\x2d\xf0\xa9\x48\xbb\x3f\x28\x0e\x06\xab\x15\xda\xca\xe2\x33\x1a\x5e\x98\x47\x9d\x14\x7d\x21\xa2\x68\xac\xd7\xd6\x12\x0d\xa6\xb7\xa1\xce\xb3\x10\x4b\xf8\xf4\x7b\x4d\x3f\x9b\x04\x11\x2f\xec\xbc\xf8\x1e\x2f\x60\xfb\xbc\x35\xe9\x7a\xae\xb2\xdf\xf6\x3e\xb1\x1f\xad\x34\xea\x1f\x17\x67\xe8\xfc\xf4\xaa\x6f\x1a\xb5\x84\x59\x80\xd3\xad\x59\xd4\x39\x4e\xeb\x19\x54\x80\xd3\xf7\x65\x4f\xc5\x84\x76\xf2\x64\xba\x1a\xb9\xd3\x6a\xa5\xe6\x1a\x9b\x63\x59\x95\xd0\xef\xcb\xa1\x36\xc3\x52\x10\x12\xa7\x59\x73\xe8\xbb\x73\x02\x03\xd6\x4a\x09\x7a\x79\xab\xce\xad\xa2\x8f\xbe\xa3\x4d\x76\x5d\xba\x34\xb5\x80\x59\xc2\x66\xf6\xf6\x21\x69\x64\x7b\xb4\x24\x27\x21\xe2\x2a\x84\x42\x88\x66\x8c\x87\xc0\x21\x44\x9a\xa1\x83\xd4\xd8\x91\xe0\x41\x37\x27\x0b\x40\x02\x73\x89\x30\xe7\x6c\xa9\xe7\x56\x0b\x34\x63\x32\x0e\x8b\x7b\x8a\x33\x5b\x83\xaa\x9f\xc3\x5c\x79\x86\x4f\x36\xcd\x16\x83\x57\xb6\x8e\xf4\x04\x41\x0c\x0b\x5e\x3b\x10\xee\x71\x73\xe6\x84\xd6\x85\x8b\xc2\xb3\xdc\x87\xab\xa4\xd9\x85\xcb\xcc\xd0\x07\x58\x87\xa3\x6b\x6a\x09\xe9\x53\xbb\xef\xa4\x98\x47\x84\x8e\x66\x4c\x4a\x96\x9e\xa0\xe3\x49\xf6\xfc\xb7\x9a\x0a\xb5\x0b\x2e\x60\x28\x5f\x91\x91\xe0\xa9\x50\x7b\xe1\x89\x4b\xd3\xbd\xa4\x21\x3c\x97\x15\x12\x8e\xa9\xd2\x6a\x4a\xa8\xef\x4c\x1c\x94\xe2\x67\x9d\x4c\x4d\xb6\x28\x55\x79\xf7\x91\xf1\xc2\x0d\xe7\x58\xe3\xae\x92\x35\xd5\x91\xe2\x67\xd3\xfe\x8c\x86\x7f\x27\x0b\x50\xee\xb1\x24\xbc\x57\x9b\x81\xd7\x5d\x64\xbf\x28\xd0\x8a\xba\x75\x88\x31\x07\x68\x07\xde\x1a\x8d\x56\x7f\x2b\x8c\x54\x21\xaf\xf0\x4a\xa2\x1d\xea\x2a\xa7\x6d\x25\x8f\x21\xe1\x2a\x7d\xfc\xf7\x50\x19\xe4\xf0\x68\x98\x67\xc3\xa3\xa1\xda\xd8\xc3\xa3\xa1\xb6\x85\xe1\x7f\x1c\xdb\x95\x11\xfa\xa2\x9d\x75\x87\xbb\xd7\x32\x63\x0b\xf8\x14\x12\x5e\x33\x31\xdb\xc8\x56\x2b\xa4\xa6\x5c\xaf\x8d\x15\x37\x0b\x22\xa5\xf4\x9a\xe1\xa6\xb9\x29\x76\x07\xff\x52\x14\x57\x2c\x84\x2e\x31\x08\x48\x20\x90\x0d\xeb\x5a\x10\x58\xaa\x01\x0d\xe4\x2c\x93\x84\x51\xb4\xc0\x49\x0e\x4a\x5c\xf3\xb9\x33\xfd\x42\xe6\x73\xe0\x40\x03\xf0\x5c\xf3\x7e\xd7\x18\x8e\x97\xce\xf4\x0e\x2f\x8d\x13\xea\x1a\xe1\xb9\x06\xd1\x7b\xd7\x7b\xa7\xf6\x43\xef\x05\x9b\xdd\xb3\x0b\x79\xcc\x72\xee\x4c\xff\x60\x39\xef\xb3\xce\x10\xbf\x38\xd3\x2f\xf8\xa5\x0f\x6d\xca\xa8\x8c\x9d\xe9\x95\xfa\xe9\x43\xff\x02\x98\x3b\xd3\x3f\x01\x77\x22\xd9\x2f\xbf\x1d\xbb\xb3\x16\xf7\x3e\x34\xd9\xde\xb5\xeb\x77\xe9\xb3\xd0\xa6\x3a\x04\xb7\x94\xb9\x33\x5d\x55\x5a\xad\xf2\x3b\x75\x30\x73\x7a\x38\xa4\x37\x67\xfa\x8f\x02\xf8\x2b\xf3\xe9\x1a\xc0\x5c\xd4\xfc\xf0\x1b\xb2\xfb\x5b\x2c\x3a\xdc\x5c\x7f\x04\x19\x16\xe2\x0d\x89\xfc\x9b\x25\xd6\xf7\x18\x42\xf3\x74\xd6\x0e\x7f\xaf\x38\x84\xfc\xca\x93\x5a\x0d\xca\xde\x53\xda\x2f\x90\xe2\x17\x22\x9e\xd4\x6e\x0a\x60\xa4\xce\x0e\x1d\xf0\x77\x9f\x4d\x4c\x1c\x42\xba\xfc\x5c\x5b\x4c\x48\xc4\xd3\x57\xc5\x17\xad\x9b\x71\xad\x83\xc6\x47\x87\xdd\x6f\x6a\x2e\xb5\x48\x2e\x3a\xc6\x7f\x46\xc3\x1b\x53\x87\xbe\x99\xcf\x6b\xa7\xad\xae\xe3\xd6\xeb\xd5\xab\xc5\x74\x86\x45\x97\x80\x7a\xeb\x57\xc1\x55\x3c\xfe\xaf\xfa\xe5\x2c\x9b\xb1\x67\x74\x7a\x7b\x89\x24\x7b\x02\xfa\xae\x05\x18\x66\xa7\x19\x79\xdf\x66\x29\x41\xbd\x5b\xa0\x86\xcf\x1b\x65\xfa\xb6\x12\xc2\x56\x83\x2f\x4b\x00\xdb\xcd\xdd\xaa\x33\x74\xf5\x77\xa4\xd1\x5d\xf5\x85\x2d\x76\xfe\xb6\xaa\x82\x9a\xe4\xd7\x55\x14\x3a\x73\x81\xcd\xa3\xf5\x64\x1b\x0d\xcb\xf9\x9e\x3b\x9c\xb3\xc7\x87\x87\x9b\xeb\x56\xed\xa1\xab\xf0\xb0\x3d\xb5\x40\x3f\x7f\x56\x79\xc8\x38\xca\x08\xab\xf4\xd0\xac\x4e\x7c\xbb\xbd\xbc\xa9\x97\x27\xa2\xfe\xc5\x89\xe9\x99\x16\xd1\x8e\x64\xa8\xb0\x43\x9c\x90\x88\xaa\x63\x76\xe7\x89\xbe\xa1\xcc\x14\x0b\x41\x16\x80\xea\x4a\x0d\x8b\x72\x93\xef\x44\x63\xc9\xa2\x28\x21\x54\x2f\xb3\x68\x95\x4b\xb3\x2e\x79\x09\x8d\x4e\xd0\x86\xf8\x08\xe9\x0b\xbd\x93\xcd\x88\x23\xc4\x21\x54\x6d\x5d\xb4\x5e\x3b\x05\x1c\xcb\x84\x4a\x52\xdb\x80\xb6\xde\x25\x6a\x1b\x7a\xd0\xf4\x07\xdd\x36\xd4\x10\x8e\x9e\x16\xa5\x20\x84\xae\x39\x14\x5a\x8c\x9a\x35\xf4\xd5\xaa\x84\xb8\xd9\x13\x7b\xed\x6f\x50\x15\xab\x2c\xdb\xba\xbc\xfe\x7a\xd3\xfb\x72\xb0\xae\xa4\x1e\xc6\x70\x49\x55\xe6\x8a\x55\xce\xbd\xc3\x22\xf4\xf5\x66\xd3\x0a\xaf\x70\x10\x13\x0a\x75\x43\x4c\x3b\x13\xe4\xa2\xb2\x51\x18\x14\xe8\x56\x7b\x47\x69\xab\x9a\x9e\xdf\x3e\x7a\xee\xac\x6b\xcf\xd6\x58\xee\x60\xb2\x5a\x99\x12\xd1\xa7\xb4\xbc\x4b\x0b\xb2\xfc\x77\xb4\x5e\xff\xb6\x8f\xe9\x6b\x70\x5e\x41\xca\xf8\xcb\xfb\xa1\x66\xc0\x83\x0d\xd2\x54\x73\x7d\x14\x10\xba\x8d\xbe\x07\x26\x71\xf2\xe1\xab\x50\x79\xc4\x47\xaf\x41\xa5\x15\xf5\x15\xa8\x9e\x8f\xc3\xbf\xa9\x8d\x35\xbf\x80\xa8\x2d\xed\xbb\xe9\x7b\xdb\xea\xfa\xcc\xd1\xfd\x09\xc6\x47\x6a\xe7\x1b\x7b\xbf\x6e\x2a\x88\x11\xfb\xfe\x4b\x40\xde\xb1\x5c\x12\x0a\xe2\xfd\x50\x2b\x7b\x89\x58\xc9\xf4\xc3\x05\x8a\x3e\x6a\xdf\x5a\x60\x0d\x4b\xf4\x13\xe9\x0f\xbf\xd0\x7a\x7d\xf6\x91\x98\x1f\xb3\x77\x82\xad\x5d\x6b\x56\xe6\x90\x67\x0f\x24\x85\xae\x7b\xd2\x0f\x41\x7d\x96\x93\x44\xfe\x12\xe0\xb3\x9c\x24\x61\x1f\xec\xfb\x2f\x8d\xee\x2f\xee\xbe\x5f\xdc\xa1\xf3\x9b\xeb\xaf\x97\xdf\xfa\x06\x59\x9c\xcb\x78\x57\x85\xa8\xf5\x55\x63\x2e\xe3\xc6\x67\x8d\xb9\x8c\x5f\x11\xa3\x15\x03\xa0\x92\x04\xed\x30\xbd\xeb\xc6\x77\xc7\xc1\xc7\xaa\xf1\x98\x0f\x31\xea\x47\x9e\x9d\x07\x1e\x05\xbe\x59\xe6\xf1\x5c\x3d\x62\xaf\xae\xbb\x61\xdc\x62\x21\x96\x8c\xb7\xea\x26\x35\x18\x59\x41\xb4\x1d\x8a\xa9\xf7\xf4\x85\x22\xf2\x59\x4a\x64\xf3\xb0\x55\x20\x6a\x21\x69\x9d\x5c\x74\xa9\xbc\x7d\x7c\xd1\x88\x3a\xce\x2f\xbd\x8e\x2f\xad\xcc\xb3\x66\xcb\x9b\xdb\xd0\x8e\x03\x4b\xeb\x4b\xb6\xf2\x57\x04\x9c\x64\x12\xe9\x5b\x31\xf7\x87\x70\x17\x40\x43\xc6\xdd\x94\x29\xeb\x1d\xff\xd0\x02\x33\x34\x3b\xa8\xe7\x20\x83\xb8\x2f\x31\xa6\x51\x9e\x60\xae\x3f\x48\xdd\x3f\x24\x61\xcf\x7b\xa9\x08\x25\xfb\xa1\x6e\x76\x9c\xfe\xbc\xf5\x15\xe4\xea\xc8\xf9\x0a\x72\x7d\x32\x7b\x05\x7d\x6a\xd2\xe4\xd7\x0c\x29\xaf\xd6\x5f\xb3\x66\x65\x78\xfb\xe8\x43\xc2\x41\x7f\x32\x21\xf6\x92\x0a\xe0\x0b\x12\xb4\x08\x3d\xd7\x7c\xcb\x72\xe0\xb9\xfa\xbb\xee\xff\x05\x00\x00\xff\xff\xa7\x9c\x14\xbb\xe7\x2d\x00\x00")
+var _indexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xdd\x1c\x6b\x6f\x1b\xb9\xf1\xb3\xf3\x2b\x98\x45\xef\xe4\x14\x96\xe4\x14\x38\xb4\x70\x25\x15\x8e\xe3\xe4\x0c\x24\x76\x60\x2b\xd7\x5e\x0f\xf7\x81\xda\xa5\x24\x26\xfb\x2a\x97\x2b\xd9\x48\xfc\xdf\x3b\x43\xee\xae\xc8\x7d\x69\x25\x39\xe9\xa1\x41\x60\xed\xf2\x31\x1c\xce\x7b\x86\x94\x46\xcf\x5f\xdf\x5c\x4c\x7f\xfd\x70\x49\x96\x32\xf0\x27\xcf\x46\xf8\x41\xc2\x45\x9f\xc6\xf1\xd8\x71\x69\x22\x7d\xe6\xe0\xbb\x1b\x85\x52\x44\xbe\xcf\xc4\xd8\x39\x8f\xe3\x8b\xe2\x95\xd0\x84\xc0\x60\x67\xf2\x0c\x26\x33\xea\x4d\x9e\x1d\x8d\x02\x26\x29\x71\x97\x54\x24\x4c\x8e\x9d\x54\xce\xfb\x7f\x73\x8a\xf6\xa5\x94\x71\x9f\xfd\x27\xe5\xab\xb1\xf3\xaf\xfe\xc7\xf3\xfe\x45\x14\xc4\x54\xf2\x19\xae\x84\xcb\xb0\x10\x26\x5d\x5d\x8e\x99\xb7\x60\x27\xee\x52\x44\x01\x1b\xbf\x74\xc8\xb0\x80\x10\x52\x68\x71\x56\x9c\xad\xe3\x48\x48\x63\xd2\x9a\x7b\x72\x39\xf6\xd8\x8a\xbb\xac\xaf\x5e\x4e\x08\x0f\xb9\xe4\xd4\xef\x27\x2e\xf5\x01\xce\xe0\xf4\x84\x04\xf4\x9e\x07\x69\xb0\x69\x52\xc8\xf9\x3c\xfc\x4c\x04\xf3\xc7\x0e\x07\x80\x0e\x91\x0f\x31\xac\xc2\x03\xba\x60\xc3\xfb\xbe\x6e\x5b\x0a\x36\x07\xb2\x24\xc9\x50\x93\x66\x10\x87\x8b\xd2\xe4\x44\x3e\xf8\x2c\x59\x32\x26\xcd\xe1\x09\x0b\x68\x28\xb9\x3b\x08\x78\x38\x80\x86\x4e\x93\x80\xac\xc5\x58\xc9\x61\x39\x64\xc4\x8c\x87\xde\xd8\xf1\xa8\xa4\x03\xa4\x03\xf9\xfa\x95\xf4\x2e\x14\x32\xb3\x48\xf6\x9c\x49\xf1\x3c\x1a\xaa\x39\x38\x59\x81\x87\x87\xa3\x59\xe4\x3d\x90\x2f\xf0\x70\x14\xc5\xd4\xe5\xf2\xe1\x8c\x9c\xc2\xdb\xe3\xb3\xac\x6f\xe0\x47\xd4\x63\x5e\x69\xc8\x4b\x35\xe4\x68\x34\xcc\xe0\x8c\x86\x9a\xd1\xcf\x46\x0a\x1e\x4a\x87\x4f\x93\x64\xec\x7c\xd1\xd3\xcf\xa4\x48\xd9\xa3\x42\xdb\xe3\x2b\x92\x75\xa6\x9c\x04\x2c\x4c\xb1\xb9\xdc\x8e\x0c\xa4\x3c\x64\x42\x75\x1e\x8d\x68\x46\x04\x14\x95\xe4\x6c\x38\x5c\x70\xb9\x4c\x67\x03\x37\x0a\x86\x9f\x62\xee\xfb\x91\xa0\x19\x03\x60\x9f\x4e\x0e\x08\x91\x02\x79\xe4\x92\x05\x1a\xce\xd1\x88\xe7\x7d\x73\x10\x14\x42\xd7\x2c\x01\x61\x22\x8a\x97\x93\xd1\x90\x67\xa3\x92\x98\x86\x3b\x92\x16\xa7\x68\x5c\x87\x54\x7f\x3e\xef\xf7\x89\x86\x94\x2d\xa9\xf1\xf8\xf2\x05\xb5\x63\xa0\x80\xae\x98\x48\x78\x14\x92\xc7\xc7\x0c\x00\xe9\xf7\xf5\x64\x83\x1c\x82\x2f\x96\x72\x43\x29\x45\x0d\x13\xa2\x26\x37\x77\x3f\x8f\x1d\x25\x1f\x51\x38\xe7\x0b\x32\x26\xcf\x37\x6f\xd9\x44\x63\xfb\xa0\x87\x92\x87\x8b\x44\x6f\xdd\xe4\xd8\xcc\x4f\xd9\xd9\x66\xea\xa3\x49\x97\x6c\x6b\x47\x95\x6d\x11\x32\xeb\x8b\xea\x2a\x3e\xa2\x1e\xc2\x3a\x95\x65\xb2\x05\x42\xe6\x4a\x90\xae\x7f\x90\xde\x42\x30\x16\xf6\xc8\x19\xe9\x09\xe6\xf5\xac\x45\x0d\xd2\x02\x59\x94\xb4\x64\x0f\xc5\x67\x9b\xf4\x94\x3a\x13\x49\xdd\xcf\x14\x6c\x0b\xb2\x81\x2c\x04\xf7\x9c\x0a\xc9\xe5\x9a\xf9\x2b\x46\xc0\x60\x30\x00\xe6\xa7\x41\x98\x93\x1e\x99\x3a\x56\xff\xc8\xdd\xc5\xf9\xf5\xf5\xe5\x2d\xb9\xbb\x7c\xfb\xfe\xf2\x7a\x9a\x35\x8f\x73\x16\x56\xd6\x75\x29\x6c\x57\x90\x84\x2d\x80\x97\xb2\x62\x43\xef\x74\xbf\x6d\x47\xb3\x49\x05\x65\x6d\x90\x32\x8a\x09\x95\xb0\x9f\x25\xd0\xd0\xa7\x33\xe6\x9b\xc2\x90\xf2\x41\xb2\x8c\xd6\xe1\x20\x5f\x19\x44\xa2\xdc\x96\x03\xd6\x1c\x9d\x64\x38\x18\x24\x2f\x33\x7b\x0e\x0a\x2d\xfb\x4a\x24\xd5\x5a\x1c\x94\x32\x83\xa5\x45\x9a\x85\x48\x5b\xaf\x00\xac\x49\x86\x43\xc0\xf0\x92\x58\x44\xc0\xe8\x24\x29\x88\x64\x6a\x5b\x05\x16\xb0\x4a\xa6\x89\xc6\x95\xa3\x5d\xbd\xcb\x9e\x06\x83\x81\x8d\xe3\x66\x15\xa0\x04\x95\x60\x43\xd1\x11\x34\xae\xf2\xbc\x7d\x99\x7c\xce\x91\xa6\x87\x57\xbc\x6b\x20\x09\x0f\x5d\xd6\x82\x2a\xf3\xce\x81\x38\x74\x11\x4d\xfa\x65\x2c\x6d\xc2\x9a\x6f\x85\x6c\x97\xd8\xbc\x16\x20\xa6\x28\x36\x3e\x08\xa3\xa5\xa8\x1e\xf0\xf1\x8c\x94\x39\xfa\xb8\x61\xa9\x54\x52\xbe\x11\x97\x34\xdc\xc8\xbe\xea\x33\x98\x24\x33\x4f\x5d\xbc\x8b\xcd\x0b\xf6\x4e\x7e\x8e\x12\x89\x36\x10\x9c\xc8\xb2\xd4\x75\xf5\xa1\xa6\xf1\xfd\xf9\x45\x4d\xeb\xed\x74\x5a\xd3\x7a\x07\xaa\x5f\xd3\xfc\x26\x12\x76\x2b\xbc\x09\x83\x96\x36\xce\x23\x89\xbe\xc7\xda\x42\x59\xa8\x96\xb0\x87\x64\xe0\xb3\x70\x21\x97\xa0\xae\xe4\xd4\xb1\x56\xf4\x50\xd7\x91\x25\x63\xe7\xe5\xe9\xe9\x0f\xce\xe4\x3a\x22\x6a\x0a\x99\x47\x69\xe8\xc1\x82\x5e\x03\x2e\xf9\x62\x20\x76\x8c\x42\xd8\xb1\x44\x59\xb7\x56\x35\x39\xb7\x1c\xfc\x49\x3d\x15\x0a\xb4\x1c\x24\x40\x81\x73\x49\x9e\x03\x52\xbd\xd3\xd3\xd3\x97\x7d\xf5\x7f\x7a\x7a\x7a\xa6\xfe\xff\xbb\x87\xae\x67\x39\xa0\xae\xe4\x2b\xa6\x9f\x99\x10\x91\x28\x6d\x00\x9d\xcb\x52\x2d\x58\xb8\xab\x7e\x4f\x79\x17\x13\xf5\xcd\x48\x1e\xb7\x74\x06\xd4\xdd\x0e\x41\x48\x09\xd6\x3b\xa4\x61\x74\xac\x5e\x5e\xa0\x05\x6f\x9a\xb1\x79\xb3\x35\x29\xdf\xbf\x56\x9a\x92\xce\x28\x52\x77\x87\xa4\x49\x84\xb0\xe6\x10\x27\x1a\xb0\xb7\xc1\x2d\x0b\x97\x29\x4d\xf0\x8a\xfa\xb2\x45\x55\xd1\xc8\xe7\x6e\x75\x46\x13\xee\x5a\xb6\x1e\x19\x5d\xf5\xc8\x47\x23\x08\x44\x02\x03\x02\xbe\x1a\x7a\x69\x39\xa5\x88\xcc\x39\xf3\xbd\xc4\xe0\xba\x39\x40\x75\x5a\x12\xa1\x7c\xc2\xe4\x0a\x82\x61\xb1\xa2\xfe\x68\xa8\xdf\x8d\x01\x3c\x8c\x53\x99\x05\xb6\x92\xdd\x6b\x4c\x83\xc8\x53\x21\x68\x26\xbf\xf9\x9e\x06\x3c\x83\x63\x2e\x6f\x90\xa2\x23\x36\xe7\x5a\x86\xa7\x10\xd0\x81\xe1\xf2\xbd\x43\xb1\x92\x39\xa0\x46\xb4\x4a\x6f\x06\x8e\x2e\xc3\x2d\x91\x32\xaa\xa3\x59\x2a\x65\x14\x1a\x5c\xd1\x0d\xa6\x77\xcd\xf1\xd0\xfe\xee\xf8\x79\x9d\x13\x7c\x61\x6e\x1f\xd4\xa5\x6e\x0c\xc6\x3e\x97\xfa\x51\x45\x3f\xaf\x79\x92\xbd\x3c\x3e\x1a\xfb\xd1\x08\xec\x87\x62\x1a\xc3\x82\xec\xd8\xc2\xc6\x88\x04\xe9\xca\x0c\x80\xef\xe0\xb5\x79\x5d\x8b\x94\xa3\x21\x0a\x6b\x55\x29\xcc\x47\x23\x5e\xfa\xe7\xe5\x2b\x72\x71\xfe\xbe\x6b\xbc\xb4\x66\x33\x97\x06\x8d\xe1\xd2\x05\x0d\xec\x50\x09\x06\x1f\x16\x26\x65\x0b\x9a\x51\x92\x6e\x2a\x05\x49\xc0\x48\x68\x1c\xe8\xfc\xab\x88\xdc\xb7\x05\x4b\x9b\x69\x01\x03\x37\x1c\xc4\xe5\xa9\x07\x07\x00\x1a\x59\xc3\xff\x77\x32\x4d\xb5\x7a\xa1\x72\xdc\xfe\x26\x8c\xce\xed\x17\x6e\x60\xe6\x47\x33\x53\x57\x78\xb0\x30\xcd\x97\x9f\x72\x8f\x08\xf4\x97\x40\xec\x59\x24\x20\x01\x83\x07\x05\xd0\x21\x38\xb7\x9f\x08\xb7\x1e\x92\x81\x80\xcf\xe6\x90\x9d\x81\x87\x5b\xab\xb5\x71\x83\x7a\x4e\x2c\xd8\xea\x2e\xa4\xb1\xc9\x41\x6c\x87\xec\x10\xcd\xc0\xb1\x39\xa6\x41\xe0\x51\xd6\x89\x5a\x00\x04\x62\x25\xac\xcc\xaf\x6a\x2e\x6a\x52\xb1\x3a\xbc\x42\x30\x53\xdb\xf0\xca\xc7\xb4\xe1\xa5\x57\xe8\x82\x58\x8d\x55\x2b\x73\x89\xa8\xf4\x7c\xec\x04\x54\x2c\x78\xd8\x87\x3c\x55\x46\x01\x64\xef\xa7\xf1\xfd\xdf\x2d\x16\x2a\x7b\x9b\xa1\x81\xb6\x22\x06\xfc\x33\xb6\x67\x66\x37\x17\xdd\x2b\xe0\xeb\x7d\x5e\x0a\x11\x34\x44\xae\x06\x1c\x22\xa6\x53\x07\xab\x28\x2a\x72\x3a\x6d\x60\x2a\x9a\xf2\xbe\x36\xb9\x25\xc3\x68\x41\xc7\xc8\x0c\x1b\x00\x9c\x7e\x07\x0b\xf9\x0e\x5c\x06\x9a\xc7\x7c\xe0\x1d\x2a\x83\xb0\x4d\x64\x37\x93\x5f\x71\xb1\x36\x8a\xe0\x4c\x58\xd5\xcb\x5a\x63\x14\xfb\x2b\x3e\xa3\xf0\x6f\x99\x55\x4a\xaa\x7e\xad\x30\xda\x46\xa4\xe8\x71\x81\xb1\xe2\x6f\x3d\x14\xc8\xde\x49\x2f\x8d\xe1\x0f\x2a\x36\x7c\x28\x59\xe8\xfd\xee\x98\xa6\x8c\x87\x0f\xca\x58\xd7\x98\x7b\x45\xb3\x68\xc5\x8e\x01\xa6\x25\x62\xa6\x90\x01\xb1\x71\xc9\xc7\x47\x2d\xc5\xe5\xca\x47\x4e\xbd\xb2\xbb\x29\x2b\x45\xbb\xa7\xcf\x49\xf1\x1e\x64\xa7\x8e\x0c\x09\xf3\x21\xef\x2f\x49\x17\x56\xef\x70\x42\x09\xf3\x28\x96\x58\x21\x81\xb0\x23\x65\x48\xae\xf9\xdc\x99\xbc\x86\xbf\x60\x56\x20\xe2\x1b\x0d\x75\x7f\xdb\x1c\x41\xd7\xce\xe4\x96\xae\xb5\x11\xaa\x9b\x01\x76\x58\x61\x74\xe8\x7e\x6f\x51\x1f\x3a\x6f\x58\x6b\x4f\x1b\xe6\xcb\x28\x05\x4d\xf9\x19\xfe\x76\xd9\xa7\x47\x1f\x80\x34\xf4\xa1\xcb\xd8\x00\x84\x74\xe9\x00\x83\xe0\xa3\xcb\xf8\x07\x46\x01\x93\x5f\xe1\xef\x7e\xf4\x6b\xd1\x4e\xcb\xef\x3d\x69\x64\xdd\xa6\xf5\x6d\xfc\xcc\xb8\x89\x19\x6f\x85\x99\xad\xb1\x29\x72\xb5\x88\x4b\x31\x0b\x73\xea\xb7\xfc\x34\x61\xfd\xc7\x04\x2b\x35\x3b\x05\xcf\x16\x82\x69\x62\xd9\xe1\x3d\x42\xf9\x0f\xd0\x7b\x08\x06\x31\x4d\x92\x3d\xa2\xf6\xbd\x29\xd6\x35\xe7\x08\xd3\x60\x56\x75\x7f\x3b\x64\x1c\xdf\x32\x2d\xb3\x50\xd9\x9a\x92\x7d\x03\x2a\x42\x86\xf2\x19\xb5\xc9\x65\x7d\xcc\x1d\x6a\xd0\x6f\xcf\x4d\xb4\x1f\x22\xaa\xce\x6c\x6d\xc6\x03\xc0\x6f\x10\x2e\x79\x2c\xfb\xb5\x9a\x31\x10\xa7\xd7\xf7\x58\x26\x35\x0b\x2e\x6a\xe6\x43\x54\x71\xa3\x0b\xce\x37\xf3\xb9\x95\x6d\xd5\xa5\x5b\xbb\xb3\x57\x91\xe9\x15\x4d\xea\x08\xd4\x99\xbf\x88\x2e\xc2\xf8\xae\xfc\x15\x51\x3c\x8b\xee\xc9\xf9\x87\x2b\x48\x9d\x3e\x63\x69\xee\x80\x0d\x68\x60\xe7\x31\x3f\x4c\x59\x72\xa4\x0e\x26\xa8\x86\xb3\x27\x4d\xf7\xab\x17\x94\x05\x3e\xcf\xfc\x2b\x52\x9e\xd7\x12\x8c\x41\xe5\x12\x82\x39\xbf\x59\x82\xf7\xab\x17\x20\xec\x6f\x57\x2b\xa8\xf5\xf2\x0d\xa5\x82\x77\x37\x6f\xef\xba\xd6\x09\xfc\x08\x82\x82\xa6\x2a\xc1\x3b\xe8\xb4\xcb\x04\x38\xfc\xb0\x3a\x81\x5a\xd0\xac\x12\x98\x20\xb3\x1a\x01\xae\xfb\xb4\xd9\x3d\x2e\xd2\x98\xdb\x7f\x83\xa2\x21\x84\xe2\xa9\x5f\xa7\x6a\x95\x58\x16\x31\x83\xc4\x03\x87\xeb\x0d\x2c\x31\xb0\xcd\xda\xf1\xfc\x17\xa4\x09\xdb\x75\xdc\x88\x89\xae\x87\x8c\x80\xbf\x80\x36\x39\xe6\xde\x09\x09\x5e\x60\x12\x54\x1c\x8b\x6a\x60\x89\x2a\xd9\x96\x02\xcb\x3d\x4c\xc7\x3b\xb6\x62\x75\x4e\xb6\xb2\x91\xf2\xfa\xbf\x19\x3b\xfb\x1d\x19\xa0\x20\x55\xf7\x08\xc6\x45\xf5\x1c\x77\x03\x50\xa2\x86\xaf\xc8\xe0\x23\x01\x34\xc5\x70\x4c\x87\xbd\x37\xaa\x57\xf5\xe0\x67\xc5\xc4\x03\x71\xf1\x52\x05\xec\xb7\xf5\x14\xa8\xf1\x08\x45\xa1\x06\x1a\x26\x38\xdb\xf1\x04\xc5\x57\xaa\xd0\xf9\xe8\x84\x15\x84\xc8\x56\xb3\x94\x62\x4d\x05\x1e\xd1\x9d\x11\xa6\xe9\x84\x38\xf4\xb0\xb1\x77\x42\x42\xb6\xa0\x58\x5a\xb6\x3b\xd5\x31\x49\xef\xb1\x82\x67\x66\xd0\xc1\x2e\xd0\x38\x51\xa7\x7e\xd6\x41\x02\x53\x65\x06\xeb\x40\xa2\x7a\x0c\x51\x07\x05\x6c\x74\xbe\x7c\xd3\xa9\x09\x1b\x04\x2c\x49\x20\x17\xad\x8c\xd8\xeb\x0c\x62\xf3\x68\x3c\x99\x7a\x01\xf9\xe3\x96\xa3\xec\x57\x1f\xa7\xd3\x9b\xeb\x8a\xc5\xad\x33\xb7\xcd\x89\x17\x1e\x16\x95\x35\x60\xb0\x88\x79\x54\x9c\x09\x97\xad\xf3\xdb\x0f\x57\x37\xb6\x75\x5e\x74\x37\xcd\x93\x57\xca\xdd\xb4\xa4\x8c\x99\xb7\xa6\x3e\x5f\xe0\xb1\x70\x7d\xdd\xb3\xe4\x18\x03\xf8\x8b\xc7\x13\xb6\x83\xf4\xb2\xa2\xfc\xd8\x59\x0c\x64\xb4\x58\xf8\x78\xa5\x01\xb6\x9b\xbd\xe5\x5b\x33\xee\xbc\x28\x21\xdd\x0c\x3e\x21\xea\x7e\xc3\xd9\x66\xc6\x09\x11\xcc\xc3\x77\x25\xa0\x8f\x4e\x86\x8e\xe1\x70\xf2\xa1\xa6\x33\x6e\xbc\x5a\xa1\xfc\xf1\x54\x8d\x2f\xe4\xc5\xf6\xc7\x25\xe2\xa8\x65\x49\x26\x88\x05\x37\x17\xe5\x63\x45\x10\xd7\xac\x6d\x13\x5f\x6c\xf7\xe5\x85\xab\x36\x64\xec\xea\xfa\xcd\x4d\xe7\xbb\x12\x36\x93\x3a\x08\xc3\x55\x88\x3e\x90\xa2\x4d\x6d\x91\x08\x75\xdb\xa3\x2c\x85\xef\x01\x10\x0f\x99\x2d\x88\x41\xad\xab\xcd\xea\xbf\x99\x40\x31\xf5\x56\xd5\x2c\x25\x55\x93\x8b\x0f\x1f\x81\x05\xf5\x06\xda\x00\xd9\x02\x04\x68\xaf\x0a\xe9\xc7\x41\x7e\xbd\xc0\x8d\xd3\x17\xc0\x87\x1f\xb6\x01\xdd\x05\xcf\xf7\x2c\x88\xc4\xc3\xe1\xa8\x42\x14\xe3\x6e\x30\x0d\x14\xd4\x8f\x09\xf3\x86\xa5\xb6\x69\x24\xa9\xff\xe4\xbb\xc0\x6c\xeb\xa9\xf7\x80\xc9\x97\xbd\x03\x6c\x79\x3a\xfc\x37\x27\x08\xe5\x0b\x61\xd6\xd6\x7e\xd1\x6d\xfb\xed\xae\xcb\x1a\xf5\x37\xd2\x9e\x92\x3b\x6f\xa3\xc3\x79\x53\xa0\xb8\x88\x7e\xf9\x26\x48\xde\x46\x29\xe4\x88\x2c\x39\x1c\xd5\x42\x5e\x16\x51\x0e\xf4\xc9\x09\x4a\x9e\x4a\x6f\x0d\x64\x35\x48\xf2\x95\xa8\x7b\xb0\x80\xf2\xab\xa7\xc4\xf9\x63\x7c\x20\xb2\x56\x80\x56\x88\x43\x1a\x4f\xcb\x91\xda\x53\x62\xfd\x2a\xe5\xbe\xfc\x26\x88\xcf\x00\xb2\xd7\x05\xf7\xed\xf9\xf2\xdd\xe5\xed\x2f\x97\xb7\xe4\xe2\xe6\xfa\xcd\xd5\xdb\xae\x4e\x96\xa6\x10\xc3\xb7\xd4\xd1\x2b\x97\xbc\x61\x7c\xe9\x96\x77\x8a\x87\x08\x9d\x7d\x34\x02\x60\x78\xe7\xb9\xea\xa6\xdb\x2e\xc1\xb4\xe4\x78\x46\x25\x5c\xdf\x4d\xb3\x93\xbc\xd6\xb2\x10\x22\x5f\x2e\x86\x43\x10\x85\x33\xb6\xf2\xba\x1e\x0d\x2c\x87\xaf\x23\x51\xa9\x2e\x5b\x68\xc4\xd9\xa0\x66\x54\x74\x55\xbc\x2b\x2a\x49\x3a\x0b\xb8\x2c\x97\xa4\x32\x8c\x2a\x98\x54\xaa\x40\xea\x40\xb1\x5a\x0a\x52\x18\xd5\xd4\x82\x3a\x95\x82\x2a\x91\xa7\x25\xcb\xe6\x9d\x11\xe3\xd0\xba\x92\x39\x68\xa2\x68\xd3\xe4\x47\xee\xe7\x3c\xed\xdc\xc8\xc5\xf2\x27\x63\x23\xfa\xae\xb7\x33\x79\x07\x43\x41\xe4\xc0\xec\x8e\x86\xcb\x9f\xda\x53\x62\x9d\x11\xe4\x89\x71\x25\x19\xb6\xf2\x52\xbf\xae\x42\x51\x45\xd2\xa4\x95\xce\xf6\xfc\xc1\x67\x1e\x7a\xd5\x54\xaf\xe8\x65\x0f\xcd\x9d\x73\xca\xfd\x54\x28\xf7\x41\xf2\xe7\xea\xd0\x14\x94\xca\x27\x6a\x82\x7e\xfc\x4a\x90\x73\x67\x4e\xc0\x3c\x9e\x06\x4e\x19\xbe\x99\x66\xda\x69\xe5\xae\x12\x12\xe2\xa6\xeb\x13\x14\xdd\x67\x88\xc8\x47\xdd\x40\x7d\xbf\x3e\x43\xb1\xc4\xa4\x96\xb7\xa8\xe7\x89\xc1\xd6\xee\x5c\x2d\xa4\x4c\x61\x8d\x60\x2a\xd2\x64\xb3\x3b\x55\xec\x2e\x46\x9b\x62\xa1\x58\x93\xea\x2f\x04\x94\x09\x9b\x77\x82\x8d\xac\xeb\xac\x75\x40\xad\x85\xe4\x80\x87\xbc\xe9\xc0\x5f\xa1\x07\x1c\x96\x48\x97\xe3\xb4\xa1\x66\x8b\x03\xea\x8e\xf9\xbb\xd4\x88\xb7\xae\x2e\x18\xde\x38\x68\x5d\x5f\x0a\x9a\x2c\x3b\x21\x60\x52\xcb\x10\xd1\x52\xe1\xe3\x7f\xe5\x2a\x50\x0e\x14\xd3\x0f\x75\x13\xb7\x51\xb5\xae\x5a\x53\x8c\x2c\xd6\x44\x59\xb2\x4b\x86\x42\x95\x0c\x45\x21\xa2\x38\xa2\xa6\x62\xf8\x5d\xdd\x97\x42\x55\xb9\x2e\x12\xfb\xd4\x65\x78\x3e\x8a\x51\x43\x1a\xea\x5a\xa9\xf7\x87\x70\x69\xe8\xb5\x94\xb4\xd6\x3b\x35\xdc\x44\xc9\xa9\x11\x6c\xdb\xdd\xb3\xd5\xda\xaf\x0b\x45\x09\x12\x17\x84\xde\x58\xb2\xc3\xa4\xfa\x22\x15\x02\x22\xab\xfd\x38\x97\x77\x0c\xd4\x89\xf6\x61\xb2\x7d\xcd\xd6\x07\x22\x11\xb2\xf5\x1f\x42\x52\xb4\xd4\xe6\x3a\xd1\x20\x2f\x25\x0f\xa7\xf9\xfb\x44\xc2\x32\x5d\x47\xfd\x39\x78\x30\xd0\x74\x5a\x0a\x9c\x37\x62\x63\x04\x50\xcf\x15\xda\x01\x1b\xc8\x48\xc6\xb6\xec\x58\x23\xb0\x7b\xc0\x42\x8c\xe2\x31\xf2\x27\x3f\xfe\x48\x8c\x0e\xc1\xdc\x08\xbd\xa8\xb3\x27\xd9\x34\xe0\xe9\xcd\xf4\x43\x03\xc9\x82\x68\xc6\x7d\x33\x72\xd4\x97\x9f\xbb\x10\xad\x5e\x4b\x2c\xd7\x5e\xda\xdc\x4e\xf7\x7d\xce\x3d\x0f\xd2\x16\xf2\x80\xe5\x72\x83\xe2\x48\xff\x38\xae\x5e\x04\x72\x41\x7a\xd5\x97\xf9\x6a\xd6\x1d\xa4\x82\x2b\xf7\xaf\x06\x6d\xe6\xc4\x13\x80\xa6\xab\xd2\x00\x9f\x60\xe8\xd7\x0e\x27\x61\xae\x60\x72\x03\x6a\x34\x8c\x0d\x77\xd9\x74\x4c\xdd\xb0\xc1\x8b\xba\xeb\x78\xdb\x1d\x9f\xc2\xc9\x55\x37\xf3\x4a\x7a\xd9\x82\x42\xbd\x66\x36\xa9\xe6\xae\xba\x89\x09\xaa\x08\x2a\x52\x66\x88\x19\xe4\x9d\xb6\x6a\xea\x29\x6d\xc1\x87\x75\x9c\x56\x7f\xb3\xdd\xce\x58\x1a\xf5\xcd\x08\xa0\x4a\xf5\xf5\x36\x45\xbb\x03\x51\x63\x28\x17\x09\x23\x79\x2f\x41\xba\x27\x27\x84\x41\x22\x4d\x02\x0a\x14\x51\x0e\x09\xf2\x9b\xd0\x55\x27\x65\xca\xd7\x92\x68\x4e\xa8\x1a\x3a\x20\xd3\x25\x08\x15\x05\x40\x51\xe8\x3f\x10\x75\x76\xac\x06\x9f\x59\x5c\x32\x22\x5d\xb7\x08\x23\x4a\x78\x15\x92\xe9\x9a\xf2\xd7\x78\xe8\x18\xe3\xa1\x37\xc2\x5a\x73\xb9\xcc\xc4\x3a\xb7\xea\x67\xae\x12\xbd\xb2\x08\x77\xff\xee\xcb\xff\xbb\x44\x67\x07\x4c\x6d\x12\xad\x43\x6d\x43\xa4\xb3\x6f\x8a\x1c\x2c\xd2\xb5\xde\xa7\xb8\xfc\xf3\x04\xf9\x96\x86\xb3\x25\xe1\x92\x86\x18\xe2\xf0\x4a\xc6\x25\xdb\x32\x2e\xd9\x94\x71\xe9\xce\xc4\x8d\x62\xc8\xd6\x3f\x45\x3c\x3c\x76\x4e\x88\xf3\xa2\x6e\xe4\x86\x90\xe6\x57\x45\xe5\x80\xdd\xc7\x5c\x60\x7c\x9d\x3d\x10\x05\x32\x7f\xa9\x4b\xb5\x4b\xdf\xf9\xfc\xe6\x39\xa0\x60\x2b\xa0\xd9\x14\xe9\x76\x2c\xbf\x53\x1a\xd6\xc1\xce\xf1\x24\x49\x8d\xad\x29\xfc\xc8\x68\x56\x38\x3b\xdd\xbf\x61\xeb\x6c\x42\x78\xd2\x64\xb7\x6c\x3f\x99\x4d\x55\xa2\x52\x76\xb4\x4f\x58\x58\xbc\xde\x2b\x53\x54\x58\x7d\xef\x54\x51\x2f\xfa\xbd\x72\xc5\x3b\xa5\x50\xfb\x92\x46\xab\x63\x29\x51\x14\xd4\xe3\x11\x60\x15\x7a\x27\x04\x6f\x0d\x0c\xff\x7c\x28\xf5\x2e\x33\x15\xe5\x95\x1b\x8c\x5d\x11\x95\xd2\x2f\x61\x19\x32\xb0\x79\x27\x04\x48\xfa\xd7\xbf\x9c\x2e\xff\x18\x99\x8a\x00\x03\x9a\x69\x7f\x7d\xcc\x8d\x71\xa6\x11\x0a\xa9\xf1\xda\xba\xef\x9a\xab\xd4\xdc\x35\xa9\xfc\x16\x43\xfe\x99\xb8\x82\xc7\x92\xa8\xaf\x7b\x0d\x3f\x25\xc3\x15\xb0\x36\x12\xc3\x20\x52\x91\xed\x27\x2d\x84\x6a\x4c\xcb\xe8\x39\x93\xee\xb2\xeb\x60\xc8\xbe\x52\x9f\x0a\xf5\x93\x2a\xdb\xa7\xf8\xd1\xfd\xd6\x51\xf8\x5b\x31\x5b\x07\x6d\x0e\x49\xd4\x0f\xb4\xec\x30\x1c\x6f\x5c\xee\x30\x5c\x5d\xa6\xd9\x61\xbc\xba\x46\xb5\xc3\xf8\x40\xdf\x84\xd8\x65\x4a\xfe\x1d\xd3\x5d\x68\x84\x42\xbb\x6d\xbc\x07\x9a\xab\xbe\x28\xbc\x7d\x03\x09\x13\xf8\xf3\x3e\xe5\x81\x20\xd2\xea\xf6\x14\xfe\x38\x0d\xfe\x92\xd1\x7f\x01\xd0\x3d\x6a\x23\xd9\x48\x00\x00")
 
 func indexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -411,8 +412,8 @@ func indexHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "index.html", size: 11751, mode: os.FileMode(420), modTime: time.Unix(1541684645, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf0, 0xf3, 0x4b, 0x54, 0xf5, 0x0, 0xc9, 0x61, 0xde, 0x2e, 0xef, 0x20, 0x6, 0x61, 0xb4, 0xf5, 0x3e, 0xfd, 0xc7, 0x26, 0xb1, 0xde, 0xa, 0x68, 0x39, 0xcf, 0x92, 0x75, 0xdf, 0x12, 0x95, 0x59}}
+	info := bindataFileInfo{name: "index.html", size: 18649, mode: os.FileMode(420), modTime: time.Unix(1792249199, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3d, 0xc7, 0x5, 0x53, 0xaa, 0x2e, 0xef, 0xa2, 0x1c, 0x5e, 0x72, 0xdd, 0x15, 0xf6, 0xf4, 0x6e, 0x11, 0x5b, 0xe6, 0x9a, 0x76, 0xd, 0x2d, 0xa4, 0x97, 0x41, 0x31, 0x44, 0x80, 0xf1, 0xc9, 0x82}}
 	return a, nil
 }
 
@@ -436,7 +437,7 @@ func jsControllerAppJs() (*asset, error) {
 	return a, nil
 }
 
-var _jsControllerAuthJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x90\x4d\x8e\x9c\x30\x14\x84\xf7\x3e\x45\xc9\x62\x61\x24\x64\xf6\xdd\x6a\x45\x51\x2e\x90\x45\x72\x00\x8f\x79\x33\x20\x81\x8d\xec\xe7\x90\x88\xe1\xee\x91\xcd\x4f\x67\x66\x15\x16\xa0\xc2\xe5\xef\x55\xbd\xc9\x77\x69\x24\x6d\xbd\xe3\xe0\xc7\x91\x82\x92\x5f\x13\xf7\xdf\x2e\x2d\x1b\xbc\x26\x67\x79\xf0\x4e\x55\xd1\xfa\x99\x1a\x54\x3d\xf3\xdc\xa0\xe2\x61\x22\x9f\xb8\xc6\x2a\x80\x5f\x26\xc0\x24\xee\xf1\x80\x5a\x06\xd7\xf9\x45\x1f\x72\xbf\x76\x2a\xee\x87\x58\xdf\x05\x8a\x59\x47\x62\x1e\xdc\x5b\xc4\x03\xeb\x76\x17\x02\x68\xdb\x85\x5e\xac\x99\x40\xce\xbc\x8c\xd4\x7d\x41\x64\x13\xb8\x8d\xec\x67\x81\x13\x56\x2d\x86\x6d\xaf\x04\x00\xc8\xce\xb0\xd1\x7b\x93\xa8\x3f\x60\x65\x53\x1c\x57\x83\xf3\xff\x1e\x39\x3f\x9f\x53\x18\xf7\x96\x46\x13\xb4\xf5\xf3\x9f\xcb\x8e\xf7\x77\xac\x5b\x49\x0d\x6c\x3b\x93\x43\x22\x01\xd4\x25\x74\xa1\xa4\xb9\x33\x4c\x78\x3c\xc7\x9d\x63\xf2\x6e\x72\xc8\x7f\xf8\xf4\x9b\xc9\x75\x6a\xdd\x9a\x8f\x11\x8e\x21\x65\xc5\x6a\x4d\x61\xbc\x41\x4e\x6d\xb6\xb4\xcf\x52\x98\x88\x7b\xdf\xdd\x20\xbf\xff\xfc\x21\x9b\xc2\xbe\x95\xf7\x56\x6b\xee\xc9\xa9\xa3\xdd\x95\x24\x50\x9c\x9f\xa5\x01\xeb\x5d\xf4\x23\xe9\xc1\xbd\x7a\x25\x63\xb2\x96\x22\x65\x72\x36\xea\x4c\x3a\x82\x5c\x7d\xff\x03\xb6\x98\xb0\x1f\x7e\x02\x94\x6f\x91\xdb\x5d\xe4\x35\xfe\x0d\x00\x00\xff\xff\xbd\x9d\x25\x02\x76\x02\x00\x00")
+var _jsControllerAuthJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xc5\x58\xdf\x6f\xdb\x36\x10\x7e\xef\x5f\x41\x10\x79\x90\x51\x57\xda\x73\x82\x61\xd8\xba\xa0\x28\xd0\xa2\xc1\xe6\x3c\x75\x7d\x60\xa9\x73\xa5\x56\x22\x05\xf2\x18\x2d\x70\xfd\xbf\xef\x48\x4a\xb2\x64\x2b\xa9\x93\xd4\x59\x1e\x22\xf1\xc7\x1d\xef\xbe\x3b\x7e\x77\x72\xad\x73\x57\x41\x2a\xb5\x42\xa3\xab\x0a\x4c\xc2\x7f\x77\x58\xbc\x1e\xc6\x7c\xc9\xd6\x4e\x49\x2c\xb5\x4a\xce\xac\xd4\x0d\x2c\xd9\x59\x81\xd8\xd0\x03\xcb\x1a\xb4\xc3\x05\xdb\xbc\x60\xec\x46\x18\x26\x48\x94\xfd\xca\x92\xb6\x54\xb9\x6e\xd3\x6e\x18\xc5\xfa\x11\x16\xa5\x5d\x5c\x90\x80\x1f\xa7\x16\x10\x4b\xf5\xc5\xd2\xc2\x66\x7b\xf1\x82\xa6\xb3\xac\x85\xcf\x52\xd4\x0c\x94\xf8\x5c\x41\xfe\x1b\xb3\x28\x0c\x66\x16\x75\x43\xcb\x9d\xb2\xb3\x56\xa0\x2c\x12\x9a\x60\x8c\xe7\x02\x45\x5a\x07\x4f\x6c\x3a\x51\xcb\x97\x61\xc7\xe0\x41\x3f\x1f\x4d\xf6\x7f\xfb\x56\x08\xf5\xc5\x55\xc2\x10\x22\xcd\xed\xb0\x9d\x7d\xff\x4e\xe6\x05\xab\x19\xdb\x46\x9d\x68\x1c\xd0\xcb\x22\x18\x1d\xb4\xb8\x86\x0c\x01\xd2\x31\x1c\xd7\x1f\xe3\xb1\xf1\x46\x8e\xf4\xc3\xbf\x08\x2a\x4f\x36\xdb\xe5\xd4\x84\xee\x90\x00\x71\xb2\x71\xa6\x3a\x67\xbc\xce\xfc\x96\x6c\xe7\x14\xab\x01\x0b\x9d\xd3\xd2\xd5\xf5\x8a\x86\x5e\xf7\x79\xf8\xbf\x5d\xa4\x58\x80\x4a\x3a\xef\x06\x4b\x0c\xd8\x66\xe7\x34\x63\x14\x70\xab\x29\xf0\xa5\x5a\xeb\x84\x5b\x27\x25\x58\xf0\x9a\xfd\xc6\xd4\x6b\xea\x0c\x19\xfc\x3d\x42\x59\x2b\x4c\x5c\xdc\x53\x10\x9e\x61\xd8\x87\x58\xe4\x79\xe9\x55\x89\x8a\x09\x29\xb5\x53\x68\x7b\x14\x29\xed\xc0\x07\xe2\x23\xbf\x29\xa1\x0d\x09\xc8\x29\xe2\x46\xa0\x0e\xef\x22\xaf\x4b\xc5\x3f\x0d\x19\xe4\x2c\x98\xb0\x7f\x3a\xe5\x13\xca\xab\x22\x8c\x3a\x3d\xdb\x5d\xa4\x2a\x2d\xf2\xeb\x4e\xee\x20\x58\x73\xd0\x87\x43\xc6\xb8\xbf\xb9\x5c\xf1\x63\xb1\x9e\x98\x39\xc0\x73\x3a\x78\x63\x3e\x89\x1b\xb8\x8e\x38\xcc\xa6\xa3\xf3\xb9\xd8\x1b\x76\xb1\x9f\xa5\x1d\x74\x2e\x44\x63\xc9\x1a\x61\xad\x1f\xf9\xe7\xf6\xee\x04\x0d\x3e\x66\x9c\xbd\xa4\xbb\x2b\x75\x0e\xd7\x7f\xbd\x7d\xad\xeb\x46\x2b\x50\x98\xb8\x54\x89\x1a\x16\x3f\x21\x77\xef\x8f\xf1\x64\xd7\x10\xe8\xe4\x84\xe9\x1c\x4e\x02\x4a\xe8\x7d\xbc\x5d\xaf\x75\x62\xb1\x87\xc1\x83\xe9\x9f\x74\xdf\x46\x40\x6f\xf7\x74\x1a\xa8\xf5\x61\x14\xdd\x0f\x13\xf5\xf8\x10\xfc\x79\xf9\xee\x72\x75\xf9\xb0\x4c\x7e\x16\x4c\xb3\x8c\x0c\x0a\x79\xd7\x6a\x93\x33\xbd\x66\x7e\x2c\x9d\x31\xe4\x08\xf3\x5e\xf6\x20\x0d\x7b\x86\x0a\x12\xa6\x65\x41\x4c\x0b\x57\xbb\xc5\xa3\xee\x79\xaf\xec\x2e\x8a\x9d\x9c\xf8\x20\xd4\xf6\xcc\x3c\x1d\x6e\xa2\x29\x19\xea\x6f\xa0\xec\x32\x60\x66\x41\x1a\x40\x8f\xa0\x60\x0a\xda\x88\xad\x5f\x67\xa5\x65\x5a\x55\xb7\xcc\x16\xba\x55\xf4\x2a\xa1\x07\x2f\xca\x4f\x49\x35\xca\xec\x6e\xdc\x88\x93\x43\x49\x26\x82\xe0\xf4\x8e\xe8\xe1\x8c\x17\x31\xc8\x95\xd6\x3a\xf0\x6e\x2b\x57\x55\x53\x0a\x5e\xf5\xc7\x1c\x15\x9b\x68\xd4\x93\x48\x78\xf0\xeb\xb9\x58\x98\xa0\xa7\x96\x60\xd5\x41\x37\x4b\xc4\xd8\x13\x71\x30\xee\x90\x89\x3b\xfd\x91\x35\x30\xb2\x46\x37\x17\x23\x81\x91\xa4\xbb\xb9\x3e\x16\x98\xc6\xb7\xd4\x36\x55\x89\x49\xf6\xf1\x1f\xbb\xfc\xf4\x32\x5b\xa4\xeb\xb2\x42\x6a\xf3\xfe\xd0\x24\x24\xd4\xa2\x97\x0b\x71\xc3\x94\x1e\xbe\xd9\xe1\xbf\x58\x1e\x9b\x9d\xbb\xd9\xfe\x30\x1e\x57\x1f\xfe\x7e\x02\xa3\x0f\x89\x72\x10\x9c\xc7\x67\xe0\x48\x7a\x97\x70\x27\xaf\x05\x06\x6e\xe8\xa0\x83\xa8\xe3\x8f\xb3\x3b\x30\x37\xa6\x65\xfe\x13\x38\xfa\xf4\xce\x66\x19\xb5\xb0\x4c\x54\x15\xab\xb4\xfc\x46\x5f\x02\x43\x13\xe7\x94\x9f\x39\xf6\x66\xf7\xd2\xfc\xf1\x5e\x4f\xfb\xd9\x78\x3c\xe4\xfc\xb4\x15\xaa\xd5\xaf\xd6\x42\x52\x06\x06\xa7\xa9\x30\x95\x52\x78\xdd\xf7\x95\xab\x1a\x86\x0a\xd0\x25\x35\x36\x7b\xa5\xcb\x07\xef\xfd\xec\x77\xc4\x1c\x78\x35\x3c\x89\x12\x83\x3d\xcf\x45\x87\xa0\xfc\x47\xe5\xea\xc3\xea\xea\x78\xd2\xc7\xe6\x80\x62\x1e\xc8\xf9\x11\xe0\x78\x76\x4d\xf1\x38\xdf\xf9\x7b\xc2\x4a\x1c\xf9\x5f\xab\x75\x69\xea\x27\x79\x3c\x6a\x3f\x36\xbe\xa5\x3b\xdf\xf9\x95\xfa\xf1\xf6\x51\x70\x18\x90\xd4\x5b\x9a\xdb\x11\x18\x69\x3f\x37\x47\x9d\xef\xe1\xe4\xb4\x99\x97\xd6\x7f\xf1\xdf\x05\xd6\xb8\x28\xce\xe2\x70\x5f\xa9\x9a\x62\xda\x91\xcb\xb8\x54\x2d\x59\x01\x22\xa7\xae\x96\x50\xe6\xfe\xe7\x0f\x4a\x94\x57\xab\xdb\x06\x38\xed\x17\x0d\x15\xd1\x78\xb7\xb3\xaf\x56\x2b\xfe\x38\xcc\xff\x27\x5c\xc7\xc7\xcc\x75\xf0\x33\x15\xc3\xff\xd6\xf1\x1f\x09\x9f\x2a\x0c\x1b\x12\x00\x00")
 
 func jsControllerAuthJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -451,12 +452,12 @@ func jsControllerAuthJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "js/controller/auth.js", size: 630, mode: os.FileMode(420), modTime: time.Unix(1507352420, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x90, 0x5f, 0x78, 0x1f, 0xa1, 0xd2, 0x4b, 0x53, 0xb2, 0x18, 0x66, 0x5f, 0xb8, 0xce, 0x3f, 0x1c, 0x91, 0xd5, 0x26, 0x8f, 0xc5, 0x9a, 0xf8, 0xaf, 0x44, 0x75, 0x65, 0xb0, 0xce, 0xb6, 0x66, 0xaf}}
+	info := bindataFileInfo{name: "js/controller/auth.js", size: 4635, mode: os.FileMode(420), modTime: time.Unix(1792249199, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x4c, 0xaf, 0x3c, 0xaf, 0xfe, 0x6d, 0x6a, 0xc2, 0x71, 0xd6, 0xd0, 0x45, 0x98, 0x27, 0xb4, 0x69, 0x70, 0xab, 0xf0, 0x66, 0x95, 0xa5, 0xf5, 0xfd, 0x9d, 0x97, 0x8e, 0x64, 0x56, 0xfd, 0x8b, 0x85}}
 	return a, nil
 }
 
-var _jsControllerCamJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd4\x57\x51\x6f\xdb\x36\x10\x7e\xcf\xaf\xb8\x10\x41\x21\x2d\x8a\xe4\xb4\x18\x06\x24\xf0\xfa\xd0\xee\x21\xc0\xd2\x15\x48\xb7\x21\x45\x5f\x18\xe9\x6c\x11\xa5\x48\x81\xa4\xec\x04\xa9\xff\xfb\x40\x91\x92\x48\xd9\xce\xb2\xc7\xe5\xc5\xf6\xf1\xf8\xdd\xc7\xe3\xdd\xc7\x4b\x23\xab\x8e\x63\x5e\x4a\x61\x94\xe4\x1c\x55\x42\x3e\xd0\xe6\xc3\xf8\x93\x64\xb0\xea\x44\x69\x98\x14\xc9\x99\x2e\x65\x8b\x19\x9c\xd5\xc6\xb4\x29\x3c\x9f\x00\x6c\xa8\x82\x92\x36\xb0\x84\x64\xcb\x44\x25\xb7\xb9\xfb\xe5\x5c\xfd\x0f\x53\x33\x9d\x5e\x9f\x80\xf5\xcc\xb5\xa1\xca\x60\x05\x4b\x58\x51\xae\x71\x30\x1b\x66\x38\xc2\x12\xc8\xdf\xf8\x50\xd2\x86\x0c\x76\x45\xc5\xba\xb7\xd7\xb2\x53\x64\xf2\x6e\x50\x1b\xda\xb4\x76\x65\xb4\x3e\x70\xf9\x00\x4b\x10\x1d\xe7\x83\x89\xb3\x0d\xde\x88\x0a\x1f\x61\x09\x8b\xc1\xd8\xd0\xc7\xc1\x46\x2e\x17\x8b\x45\x04\x3b\xac\x84\x8e\xc3\xfa\x86\xe1\xf6\x56\x56\x3d\x1f\x45\xb7\x24\x38\x93\xb1\xc6\xe7\xdd\xf5\x89\x37\x75\x6d\xe5\x6c\x63\xf6\x34\x1a\xc3\xc4\x5a\xbb\xc4\xb9\xd4\x55\xd4\x50\x58\x02\x15\xeb\x8e\x53\x95\xe3\xa3\x41\x51\x25\xcf\xbb\xcc\xc1\xfa\x1d\x19\x0c\xdf\xe0\xc7\x0f\x78\xde\xf5\xb9\x04\x77\x0d\xc9\x33\x74\x8a\x5f\x01\x69\x8a\x6d\x9f\xb9\x62\xf0\x25\x19\x34\x68\x6a\x59\x5d\x01\xf9\xfc\xe7\x17\x92\xf5\xd1\xae\x5c\xcc\x5d\x9a\x9b\x1a\x45\xd2\x03\xc1\x44\x52\xa1\x6e\x07\x82\xf6\xaf\x94\x42\x4b\x8e\x39\x13\x2b\x99\x10\xdd\x95\x25\x6a\xb4\xd0\xd6\x31\xb7\x50\x9e\x0c\xc0\x2e\x7b\x2d\xd8\x96\x2a\xb7\x38\x03\xe8\x3f\xfb\x9f\x53\x1e\x1b\xb9\x89\xb2\x58\x31\x35\x40\x1e\x39\xbf\xdd\x51\x10\x38\x87\x8a\xa9\x59\x0a\xfe\x1f\xc7\x2e\xfc\x49\x00\x05\x7d\xe0\x58\xbd\x87\xbe\x67\x0a\x6d\x64\x7b\x02\x43\x6b\x9d\x6d\xa9\x29\x6b\x77\x12\x62\x01\x73\xd7\xca\x3a\x77\xbb\x89\xe3\x35\x95\x9f\xad\xd1\x89\x56\x58\x5f\x41\x05\x96\xb2\x7d\x4a\x9c\xaf\xaf\xb5\xfc\x60\xed\x01\xb0\x15\x24\x21\x48\xee\xd9\x46\x27\x8f\xfa\xdd\xa8\x0e\xaf\xc7\x35\x85\x2b\x85\xba\x4e\xa6\x3c\x00\x72\x8d\x47\x77\x8f\x6a\xe1\x17\x39\x52\xf5\x85\x35\x28\x3b\x93\x78\xac\xdc\xcc\x92\xea\xef\xc6\x06\x3e\xe9\x53\xec\x15\xcb\xfb\xff\xa6\x94\x54\x61\x71\xa1\x1a\x8b\x6b\xb8\x34\x2e\xd7\x09\xf1\xcd\x8c\xd6\x9f\x64\xf6\xd3\x07\xd2\x68\x66\x1c\x32\x78\xb7\x58\x2c\x82\xdb\x0c\xe2\x59\x95\xec\x35\x6d\xfc\x39\x46\x66\x5a\x56\xc1\xfd\xbc\x78\x3a\x9b\xf9\xd3\x20\x39\x53\xc6\x15\x9a\x4e\x09\xe7\xb5\x1b\x45\xa6\x53\xdc\x99\x8a\x42\x21\xe5\x56\xe4\xc6\xb5\xc1\xe0\x05\x2f\x10\xc0\xe5\xbe\x04\xba\xd0\x33\xaa\x60\xf1\xad\x20\x4e\x02\x24\x68\xdb\x37\xa0\xf7\xf4\x7c\xe2\xeb\x9d\x6f\xb2\x42\xdd\x6f\x8a\x55\xfb\x1c\xc8\x68\x1d\xd4\x37\x3c\xdf\x0a\x6d\x13\x74\x8a\xfb\xc6\x3e\xd2\x84\x96\x77\xdf\x79\xb6\xb2\x3b\x0d\xa7\xcb\x25\xbc\x5d\x2c\xc2\x5a\x0d\x6b\x22\xf4\xfd\x82\x8f\x53\x59\xc5\x29\x1e\x48\xb8\xcc\xba\x3b\x15\xf8\x68\x80\x09\x83\x6a\x43\xb9\x5f\xb5\x89\x1e\x4c\xb0\x84\x96\x2a\x8d\x37\xc2\xb8\x28\x35\xd2\x0a\x95\xce\xd7\x68\x12\x72\xe3\xbd\x2e\x6e\x19\xe7\x4c\x93\x74\x8c\x5c\x14\x6b\x34\x56\xbb\x31\x00\x35\xb6\x75\x1b\xd9\xa0\x30\x89\xc0\x2d\x7c\xa4\x06\x0f\xa0\xfe\x4e\xb5\xb9\xb8\x95\x15\x5b\x31\xac\x48\x9a\x46\x3d\x7c\x6a\x74\xce\xf4\x5f\x94\xb3\x2a\x49\xe7\xcd\x1b\xbd\xb3\x17\xe4\x68\xab\x5a\x32\x42\x6e\x27\x36\x41\xc6\x6c\x10\x21\xb7\x79\xc5\x56\xab\xc4\xe8\x0c\x88\xc6\x52\x8a\x8a\xa4\xb0\x5c\x42\x74\x07\xfb\x41\x83\x8d\xa9\x2d\x06\x68\x34\xd0\xb5\x24\x13\xbc\xe7\xb2\x1f\xa5\x61\xa2\x33\x78\x30\x8a\xa5\xab\x63\xf4\x80\xd6\xf5\x0b\x7c\x74\x4f\xc2\x7b\xc2\x39\x24\xda\xc2\x5f\xc2\x7b\x20\x04\xae\x80\x68\xe2\x68\xbe\x92\x63\x3f\xd4\x1c\x63\xd8\xec\x31\x1c\x8e\xf4\x12\xc3\xc6\xa5\xc9\x79\x5a\x86\xcd\x7f\x60\xf8\xd2\x55\x18\x9d\xaf\xa4\x6a\xa8\x49\x48\x7d\xd5\x34\x34\xa6\xb1\x7f\xb6\x8a\x3e\x91\x14\x7e\x9d\x9f\x6c\x0e\x7c\x1e\x21\xc3\xc7\x8f\xc5\xed\x6d\x71\x7f\x7f\x7f\x1f\xe3\xef\x4e\xe6\xdf\x5e\xd7\x7b\x36\x98\xb5\xdf\x09\x6a\x0f\xb1\xdf\x1c\x9f\xf0\xd1\x4c\xa1\xac\x7b\xab\x70\x73\xd4\xfd\xb3\xc2\x0d\x09\xba\x92\x4b\x5a\xf5\x23\xd5\x28\xc1\xba\xed\x07\xd1\x64\xae\x48\xd6\x38\xef\x2f\x3f\xb1\xda\x8f\xe9\xb0\xc3\x03\x4f\xdb\x96\x3f\xcd\x3b\xe9\xd4\x8b\x2a\xbc\x79\x33\x69\xca\x5e\x92\xff\xe5\x75\x84\x49\xef\x72\x63\xab\xfa\xc0\x33\x36\x80\x07\x9b\xc6\xcc\x67\x91\x5a\x7a\x8f\x43\x56\x37\x2e\x4c\x37\x24\x05\x68\xce\x2a\x54\x50\xd6\x76\xb0\x1f\xde\x47\xfb\xfd\x43\xed\x47\xfd\x64\x9c\xfb\x47\xd3\x98\xc4\x70\x78\x1e\x32\x31\x4d\xfc\xd3\x54\x12\x3f\x64\x6e\xca\x8f\x87\x1f\xeb\x71\xe7\xb8\x84\x00\xb3\x46\x38\x2e\x6c\x6e\x7c\x7f\xb2\x32\x72\x19\xda\x5a\xaa\xcd\x01\x77\xbd\x65\xa6\xac\x83\xb3\xc5\xa5\xa0\x11\x48\x23\x85\xa9\xc9\x55\x70\x47\x16\x2b\xd7\xdd\x83\x51\xb4\x34\xc9\xbb\xcb\xa1\xab\xc2\x7b\x7c\x50\x48\xbf\x5f\xcf\xa0\xb6\x88\xdf\x5f\x40\xfa\xe5\xd5\x40\xd6\xeb\x38\xce\xeb\x09\xf5\x42\xf7\x32\x90\xd3\xc2\xa3\x48\xbb\x30\xef\x9d\xa2\xb6\x1c\x42\x81\xb4\x80\xd1\xe5\xb4\xa8\x4a\x14\x26\x7c\x70\xa3\xca\x48\xa1\x80\xcb\x45\xf8\x14\x32\xfd\x89\x7e\x4a\xfc\xbe\x34\x9d\xbd\xf5\x16\x73\x45\x4b\xd3\x8f\x8c\x97\x70\x31\x06\xb0\x30\x8b\xd0\xcb\x50\x65\x1f\x6b\x47\x6e\x3c\xe3\x48\xfa\x27\x0f\x13\xb1\x9d\x8a\xd9\xed\xce\x8d\xbc\xb9\xfb\xe3\xce\x28\x26\xd6\x49\x9a\x2b\x6c\x39\x2d\x31\x29\xbe\xe5\xdf\xaa\xf3\xaf\x67\x45\x06\xe4\x6b\x2c\x5b\x51\x41\xc7\xa3\xd7\x81\x99\x72\x6a\xaf\x51\x17\x22\xdb\x41\x55\xc8\x1f\x98\xa8\x12\xdb\x2c\xd9\x10\x21\xcd\xe0\xed\xcf\x8b\xa0\xd9\xe3\x7f\x4f\xc8\x58\xef\xf6\x9f\xa7\x29\xc0\x31\xd7\xf1\x76\xf6\xdc\x2d\xfa\x3f\x01\x00\x00\xff\xff\x35\x37\x88\xd9\xad\x10\x00\x00")
+var _jsControllerCamJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xd5\x57\x4b\x6f\xdc\x36\x10\xbe\xfb\x57\xd0\x84\x11\x68\xeb\xb5\xb4\x9b\xa0\x28\xe0\x45\x9a\x83\xdd\x43\x80\xda\x09\x60\xb7\x45\x83\x5c\xb8\x2b\xae\x25\x44\x12\x05\x92\xf2\xda\x70\xf6\xbf\x77\x46\x7c\x88\xd4\x3e\xe2\x1e\xe3\x8b\x57\xc3\xe1\x37\xc3\x6f\x5e\x64\x2d\xf2\xae\xe2\xe9\x4a\x34\x5a\x8a\xaa\xe2\x32\xa1\x57\xac\xbe\xf2\x9f\x74\x4a\xd6\x5d\xb3\xd2\xa5\x68\x92\x33\xb5\x12\x2d\x9f\x92\xb3\x42\xeb\x76\x42\x5e\x4e\x08\x79\x64\x92\xac\x58\x4d\xde\x93\x64\x53\x36\xb9\xd8\xa4\xe6\xcb\xa8\xda\x0f\x5d\x94\x6a\xb2\x00\x6d\xf8\x4c\x95\x66\x52\xf3\x1c\xc4\x6b\x56\x29\xee\xc4\xba\xd4\x15\x07\x21\xfd\x87\x2f\xe1\x9b\x3a\xb9\x64\xcd\x43\x2f\x2f\x44\x27\xe9\xa0\x5d\x73\x00\xaa\x5b\x5c\xf1\xd2\x65\x25\x96\x20\x68\xba\xaa\x72\xa2\xaa\x7c\xe4\x1f\x9b\x9c\x3f\x81\x7c\xe6\x84\x35\x7b\x72\x32\x3a\x9f\xcd\x66\x11\xac\x5b\x09\x15\xdd\xfa\x63\xc9\x37\x37\x22\xef\xfd\x91\x6c\x43\x83\x33\x69\x14\xbe\x6c\x17\x27\x56\xd4\xb5\xb9\x91\x79\xf6\x14\xd7\xba\x6c\x1e\x94\x21\xce\x50\x07\x3a\x0c\x74\xe0\x8c\x5d\xc5\x64\xca\x9f\x34\x6f\xf2\xe4\x65\x3b\x35\xb0\x76\xc7\x94\xb8\x5f\xe4\xfb\x77\x30\xd2\x73\x49\x4c\x18\x92\x17\xd2\xc9\xea\x92\xd0\x3a\xdb\xf4\xcc\x65\x4e\x17\x22\x57\x73\x5d\x88\x1c\x16\x3f\xff\x75\x0f\x9f\x68\xed\xd2\xd8\xdc\x4e\x52\x5d\xf0\x26\xe9\x81\xc8\xe0\xa4\xe4\xaa\x75\x0e\xe2\x1f\xe4\x85\x12\x90\x1f\x65\xb3\x16\x09\x55\xdd\x6a\xc5\x15\x47\x68\x54\x4c\x11\xca\x3a\x43\xc8\x76\xfa\x5a\xb0\x0d\x93\x66\x71\x04\xd0\xff\xef\x3f\x07\x1e\x79\xc3\x96\x55\xc4\xa3\x91\xe4\x0e\xf8\x00\x0b\x56\xeb\x10\x09\x76\xf9\xe7\xe1\xa1\x16\x8f\x11\x0b\x79\x29\x7f\xc0\x00\xee\xc8\x28\x39\x27\xa0\x3a\x62\xe1\xe7\x38\x76\x66\x4f\xe2\x82\xf5\x81\xf4\xbd\x23\x53\x5a\xb4\xb0\x6c\x5b\xcc\xd9\x86\xe9\x55\x61\x4e\x42\x11\x10\xa8\xc2\x96\xa6\x52\xb3\x9b\x1a\xbf\x86\x32\xc4\x5a\x1d\xdc\x1a\x72\x0c\x5b\x92\x59\xb5\x55\xe6\xe4\x8b\x40\xd5\x17\xe2\x50\xb4\xe0\xc5\x73\x12\x6f\xdc\x5b\xae\x84\x94\x6b\x92\x04\xf6\x22\x6e\xa2\xce\xa8\x65\xc7\x17\x7e\x4d\xf2\x35\xb0\x55\x24\x03\x53\x84\x43\xe7\x3c\xb8\xdb\xf7\x55\xbb\x58\x71\x26\xef\xa1\xb3\x89\x4e\x27\x16\x2b\xd5\x23\xda\x6d\xf4\xd0\xf0\x49\x1f\x04\xdb\xdb\xad\xfe\x1f\x52\x0a\x19\x15\xa1\xf4\xe9\xe7\xc2\x5a\x89\x87\x84\xda\xb6\xc7\x51\x1f\xd2\x04\xd5\x8c\x21\xa0\x64\xe4\xc3\x94\xbc\x83\xde\x1b\xc4\x3b\xb0\x87\xa1\xe8\xbb\xbf\xff\xf4\x96\x4b\x25\xf2\x20\x82\x47\x4f\x87\x84\x9f\x06\xe4\x0c\x8c\x4b\xae\x3b\xd9\x18\xad\xad\x6f\xc7\x50\x41\x46\x94\x65\x92\xb3\x0a\xc7\x81\x5f\x73\x02\x3b\x1a\x82\x51\xf1\x7e\x77\x58\x18\xd3\x23\x57\x09\xe2\xe3\xe8\x18\x5a\x75\xc3\xda\xbe\x44\xad\xa6\xf5\x27\x0e\xef\x78\x13\x8e\xb4\x7e\x53\x3c\xdf\xce\x09\xf5\x52\x37\xa7\xc2\xf3\xad\x39\x96\x09\x80\xd9\xd2\x3f\x50\xa6\xe8\x77\x5f\x9b\x98\xd0\x9d\x22\xa7\x70\xba\xb7\x10\xa6\x20\xdb\xc2\x9c\x08\x75\xef\x61\x7c\x4d\xc2\xb4\x1d\x28\x76\x4e\x18\x66\x4d\x4c\x1b\x50\x27\x65\xa3\xb9\x7c\x64\x95\x5d\x45\xa2\x9d\x08\x0e\xdd\x32\xa9\xe0\x78\xda\x58\x29\x38\xcb\xb9\x54\xe9\x03\xd7\x09\xfd\x68\xb5\x2e\x6e\xca\xaa\x2a\x15\x9d\x78\xcb\x59\x06\x0a\xd8\xe6\x79\x00\xaa\xb1\x62\x6b\x51\x73\x00\x6b\xf8\x86\x5c\xc3\xf2\x1e\xd4\x3f\x99\xd2\x17\xc0\x5c\xb9\x2e\x61\x76\x4c\x26\x51\xe9\x9e\x6a\x95\x96\xea\x6f\x56\x95\x79\x32\x19\x17\x6f\x74\x23\xb9\xa0\x07\x4b\x15\x9d\x69\xc4\x66\xf0\x26\x60\x0c\x8d\xc0\x5a\x0a\xe6\xd7\x89\x86\xb1\x4f\x15\x87\xea\x02\x47\x20\xc9\x48\x14\x83\x5d\xa3\xc1\xc6\x09\x26\x03\xa9\x15\x61\x0f\x82\x0e\xf0\xd6\x97\x5d\x2b\x75\xd9\x74\x9a\xef\xb5\x82\xee\xaa\x18\x3d\x70\x6b\x71\xc4\x1f\xd5\x3b\x61\x35\xe1\x77\xa2\x10\x7e\x4e\x3e\xc0\x85\x8d\xc0\x1c\x82\x90\xf5\x1a\xaf\xf4\xb1\xbf\xfe\x1d\xf2\xb0\xde\xf1\xd0\x1d\xe9\x98\x87\xb5\xa1\xc9\x68\xa2\x87\xf5\xff\xf0\xf0\x58\x28\x20\x4f\xd6\x42\xd6\x0c\x12\xaa\xb8\xac\x6b\x16\xbb\xb1\x7b\xb6\x9c\x3d\x83\xa9\xdf\xc7\x27\x1b\x03\x9f\x47\xc8\xe4\xfa\x3a\xbb\xb9\xc9\xfe\x85\xbf\x18\x7f\x7b\x32\xfe\xf5\xba\xda\x43\x63\x28\xbf\x83\xa6\x04\x87\xd8\x2d\x8e\x5b\x58\x1c\x4c\xa1\x7a\x2b\xf9\xe3\x41\xf5\xcf\xb0\x48\x83\xaa\xac\x04\xcb\xfb\xdb\x97\x6f\xc1\xb0\x03\xaf\xec\xc9\xb8\x23\xa1\x70\x5c\x5f\xf6\x6e\x8f\xff\x86\xc3\xba\x2b\x00\x6b\xdb\xea\x79\x5c\x49\xa7\xb6\xa9\x92\x37\x6f\x86\x9e\xb2\x43\xf2\x0f\xa6\xa3\x71\xd4\x4a\x31\xab\xf7\x8c\x31\x07\x1e\x6c\xf2\xcc\x4f\xa3\x6e\x69\x35\xf6\x49\xcd\x2d\x61\x88\x90\x68\x88\x82\x46\xc3\xe1\x79\x55\xe0\x13\xc8\xcd\x47\xfc\x7d\x55\xd8\x47\x51\xe2\x5f\x48\x5e\xe4\x49\x0c\x9f\x19\x8e\x89\xe1\x6d\x34\x5c\x46\xe2\x41\x66\xde\x43\xf1\xf5\x08\x35\xee\x8c\x2f\x21\xc0\xa8\x10\x0e\x37\x36\xf3\xd0\x79\xc6\x36\x32\x0f\x65\x2d\x34\xdb\x3d\xea\x6a\x53\xc2\xa4\x0a\xce\x16\xa7\x02\x98\xa4\x35\x3c\x4f\x0b\x7a\x19\xc4\x08\xb1\x52\xd5\x2d\xb5\x64\x2b\x9d\xbc\x9b\xbb\xaa\x0a\xe3\xb8\x84\x11\xfe\x6d\x31\x82\xda\x70\xfe\xed\x08\xd2\x6f\xaf\x06\x42\xad\xc3\x38\xaf\x77\xa8\x6f\x74\xc7\x81\x4c\x2f\x3c\x88\xb4\x0d\x79\xef\x24\xc3\x74\x08\x1b\x24\x02\x46\xc1\x69\xb9\x5c\x41\x08\xc2\x81\x1b\x65\xc6\x84\x64\x64\x3e\x0b\x47\x61\xa9\x6e\xd9\x6d\x62\xf7\xc1\x2c\x8c\x67\x3d\x62\xae\xc1\xd9\xfe\xca\x38\x27\x17\xde\x00\xc2\xcc\x42\x2d\xb8\x96\xe1\xb0\x36\xce\xf9\x33\x7a\xa7\x7f\xb1\x30\x91\xb7\x43\x32\x9b\xdd\xa9\x16\x1f\xef\x3e\xdd\x69\x09\x37\x6e\x68\x25\x92\xb7\x15\x5b\xf1\x24\xfb\x9a\x7e\xcd\xcf\xbf\x9c\x65\x40\xd8\x97\xb8\x6d\x45\x09\x1d\x5f\xbd\xf6\xdc\x29\x87\xf2\xf2\x7d\x21\x92\xed\xed\x0a\xe9\xb2\x84\x87\x3c\x16\xcb\xd4\x59\x98\x4c\xc9\xdb\x5f\x67\x41\xb1\xc7\x0f\x18\xea\xf3\x1d\x9f\x57\x83\x81\x43\xaa\x3e\x3a\x3b\xea\x88\xfe\x1f\x5f\x28\x87\x52\xd7\x11\x00\x00")
 
 func jsControllerCamJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -471,8 +472,8 @@ func jsControllerCamJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "js/controller/cam.js", size: 4269, mode: os.FileMode(420), modTime: time.Unix(1540384386, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x51, 0xd3, 0x9, 0x40, 0x35, 0x8d, 0x8a, 0x98, 0x28, 0x98, 0x87, 0x5d, 0x69, 0x17, 0x4f, 0x76, 0x9f, 0x11, 0xc4, 0x45, 0xb4, 0x43, 0x41, 0x72, 0xac, 0x45, 0xb6, 0xb1, 0xbb, 0xbd, 0x84, 0x1e}}
+	info := bindataFileInfo{name: "js/controller/cam.js", size: 4567, mode: os.FileMode(420), modTime: time.Unix(1792249199, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x11, 0xb7, 0x6b, 0x4a, 0xb6, 0xb5, 0x86, 0xa7, 0x69, 0x1f, 0x93, 0x94, 0x93, 0xcf, 0x39, 0x3b, 0xe4, 0xd9, 0xb2, 0xeb, 0x22, 0xda, 0x4d, 0xd6, 0x3d, 0x65, 0xa0, 0x0, 0xfe, 0x71, 0xe2, 0x3d}}
 	return a, nil
 }
 
@@ -496,6 +497,26 @@ func jsControllerGpioJs() (*asset, error) {
 	return a, nil
 }
 
+var _jsControllerLogsJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xad\x54\x4b\x8b\xdb\x30\x10\xbe\xef\xaf\x18\x44\x0e\x0a\x9b\x2a\x4b\x8f\x09\xdb\xcb\x12\x0a\x25\xb4\x85\xdd\x9e\x4a\x0f\x5a\x7b\x1c\x0b\x14\xc9\x48\x72\x4c\x08\xf9\xef\x1d\xc9\xef\xcd\xd2\xf6\x50\x1f\x2c\xe6\x3d\xdf\xcc\x27\x1d\x6d\x5e\x6b\x14\x99\x35\xc1\x59\xad\xd1\x71\xb6\xb7\x07\xff\x34\xc8\x6c\x05\x45\x6d\xb2\xa0\xac\xe1\x0b\x9f\xd9\x0a\x57\xb0\x28\x43\xa8\x96\x70\xb9\x03\x38\x49\x07\x9a\x02\xe0\x11\x3a\xb3\xe8\xc4\x46\x99\xdc\x36\xbd\x14\x4a\xe5\x97\x5b\x0a\x88\xb2\xd0\x78\x42\x1d\xd5\x3f\x59\x8e\xaf\xf5\x81\x8a\x30\x65\x0a\x1b\xcf\x46\x3a\x13\x4f\x74\xce\x3a\xf6\x6b\x88\x39\xa6\x4e\x29\x86\x79\x74\x27\x6a\x6c\xb0\x20\xf5\xaa\x30\xa5\x4b\xee\xb1\x27\x6f\x6b\x97\x45\x6f\x53\x6b\xbd\xbd\xeb\xb4\xb2\xaa\xd0\xe4\xa4\x1d\x20\x61\x0b\x63\x9e\x49\x54\xb5\x2f\xc9\xb4\x4d\x16\x55\x00\x9f\x59\x35\x9a\x43\x28\xe1\x13\x7c\x7c\x78\xe8\xc3\xdf\x24\xf0\xa5\x2a\x02\xef\x12\x5c\xe9\x7f\x4d\x3d\xac\xd7\xda\xca\x1c\x5e\xeb\xa2\x40\x87\x39\x74\xee\x2b\x1a\x0f\x1a\x08\x52\x69\x30\xd8\xf4\xea\x61\x5a\x31\x66\xd2\xf3\xac\xe5\x4c\x5b\x8f\x7d\xa5\x88\xb1\x76\x3a\x0e\xe9\xb8\x66\x70\x3f\x9b\xdc\x3d\xb0\x75\x94\x59\xeb\x9b\x96\xc8\x2f\xe4\xbe\x89\x31\x2b\x38\x62\x28\x6d\xbe\x01\xf6\x79\xf7\xc2\xae\x4b\x11\x7b\xe2\x1d\xb8\xa1\xb6\x43\x5f\x8d\x98\x6f\x16\x10\xcd\x22\x97\x41\x0a\xaf\x55\x86\xfc\x43\x1c\xd1\xb6\xf3\xbe\xae\xfe\x96\x8d\x68\xe8\x2d\xd1\x31\x72\x80\x0f\xb9\xc6\x04\xe9\xec\xc4\x71\xc3\x34\xb1\xdd\x89\x7a\x78\x4e\x1a\x9e\xc0\x5c\x1a\x15\xca\x27\x9a\x31\xe9\x95\xd4\x7e\x03\xc1\xd5\x78\xed\x62\x27\x24\x14\x85\x75\x3b\x99\x95\x7c\x68\x2a\xe9\xc7\xae\xda\x3a\x42\xe6\x79\x2a\xb2\x57\x3e\xa0\xa1\x7b\x92\xdc\x26\x97\xe3\xe8\x0f\x53\x28\xdd\x65\x58\x10\xe5\xf4\x99\xdf\xec\xae\xfd\x5a\x3e\xf2\x2f\xcf\xdf\xbe\x8a\x4a\x3a\x5a\x24\x65\x69\x31\x0f\xa0\x09\xf6\x38\x80\x9e\x51\xe9\x6c\x39\x35\xb2\xe0\x3d\x8e\x44\xf2\xb6\x08\x6e\x10\xcd\x98\x03\x6f\x6f\xcc\x9c\xb8\xa9\x88\xc7\xb0\x8f\xa0\xa7\x75\x66\xc3\x9a\x52\xea\x0f\x04\x5c\xa7\x20\x36\x61\xdc\xf7\x1f\x2f\x24\x46\xdc\x1b\x48\xc3\xf0\x44\x28\x73\x50\xc5\xb9\x2b\xf0\xaf\x74\xec\x09\x14\x1f\x13\x4e\x0f\x45\x80\xbe\xd6\x3b\x6c\xfa\x8f\x74\xec\xaf\xb7\x35\xfa\xdc\xde\xe3\xa6\x54\x04\xd9\x97\xb6\x31\x77\x23\x1b\x1a\x19\x88\x6a\xac\x56\x22\x59\xd2\xeb\x38\x7d\x61\x93\x76\xb6\xbb\xa9\x62\xf2\x1e\x0c\x6f\x0b\x10\x87\x71\x6e\x9f\x2d\x36\xed\x70\x99\xda\xeb\x9b\xa0\x3a\x6c\x91\x23\xcd\xd8\x9e\xa9\xf8\x18\x43\x6e\xd1\xf5\x37\x37\x65\x8b\xdc\x14\x06\x00\x00")
+
+func jsControllerLogsJsBytes() ([]byte, error) {
+	return bindataRead(
+		_jsControllerLogsJs,
+		"js/controller/logs.js",
+	)
+}
+
+func jsControllerLogsJs() (*asset, error) {
+	bytes, err := jsControllerLogsJsBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "js/controller/logs.js", size: 1556, mode: os.FileMode(420), modTime: time.Unix(1792245752, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xf1, 0x2, 0xba, 0x2e, 0xc5, 0x5e, 0x50, 0x1a, 0xaa, 0xd4, 0xf0, 0xf5, 0xaf, 0x83, 0x4a, 0x78, 0x8e, 0x87, 0x63, 0xf8, 0xdb, 0x96, 0x59, 0x1e, 0x36, 0x49, 0xd0, 0xd6, 0xf6, 0x7a, 0xb2, 0x4b}}
+	return a, nil
+}
+
 var _jsControllerMachineJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8f\xbb\x8e\x84\x20\x18\x85\x7b\x9e\xe2\xc4\x58\xe8\xc4\xb8\x5a\x1b\xab\xad\xe7\x21\x08\xb2\xd1\x44\x7f\x0c\xfc\xac\xc5\x8c\xef\xbe\x51\xbc\x90\x2d\x86\x86\xe4\x70\x2e\x1f\x93\xe9\xfc\xa8\x4b\x65\x88\xad\x19\x47\x6d\xb3\xe4\x29\x55\x3f\x90\xfe\xbe\xa4\xa4\xc0\x8f\x27\xc5\x83\xa1\x2c\x75\xca\xcc\xba\x40\xda\x33\xcf\x05\x52\x1e\x26\x6d\x3c\xe7\x78\x09\xe0\x57\x5a\x4c\x21\x8d\x16\xc1\x5a\xde\xc2\x32\x50\x67\x96\x48\xe0\x7e\x70\x8d\xc0\x99\x29\x3b\xc9\x12\x2d\x5e\x6b\x2c\x3a\x96\xec\xdd\x25\x1f\xad\xe9\x22\x59\xf5\x99\x00\x80\x44\xce\xf3\x9e\x2d\xc3\x6f\xdc\x39\x91\x14\xfb\xfb\x05\xbf\x79\x02\xe9\x76\xfe\xad\xee\xd7\xfb\x7d\xcc\xc4\x86\x8b\x20\x4e\x9c\x6a\x94\x58\xc3\x1c\x5b\xaf\x05\x90\x37\xe2\xc6\xb5\xc6\x53\x87\xf6\x46\xa1\x93\xc3\x6a\xf6\x96\xf0\x94\xdc\x07\x57\x46\x78\xa0\xae\x72\x7c\xa1\xae\xb6\xe2\x35\x2e\x9a\xb5\x55\x9f\x7a\xe2\xbd\xac\xae\x2a\x3c\x40\xf9\xd1\xb2\xe6\x8d\xf8\x0b\x00\x00\xff\xff\x19\x95\xe3\xd7\xf0\x01\x00\x00")
 
 func jsControllerMachineJsBytes() ([]byte, error) {
@@ -516,7 +537,7 @@ func jsControllerMachineJs() (*asset, error) {
 	return a, nil
 }
 
-var _jsControllerScannerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x54\x4d\x8f\xdb\x36\x10\xbd\xfb\x57\x4c\x04\x23\xa0\xb1\x8a\x64\x37\x40\x50\xac\xad\x16\x45\x7b\x68\x50\x2c\x5a\xa0\xe9\x69\xb1\x07\x5a\x1a\x9b\xdc\x95\x48\x82\x1c\xc5\x31\x36\xfe\xef\x05\xa9\x2f\xca\x4e\x8a\xee\x61\x65\xce\xbc\xf9\x78\x8f\x9c\x69\x74\xd5\xd6\x98\x95\x5a\x91\xd5\x75\x8d\x96\x25\x7f\x97\x5c\x29\xb4\xbf\x8e\xa6\x24\x85\x43\xab\x4a\x92\x5a\xb1\xa5\x2b\xb5\xc1\x14\x96\x82\xc8\xa4\xb0\x24\xd9\xa0\x6e\x69\x05\xaf\x0b\x80\xcf\xdc\x82\xeb\xa2\xa1\x80\x1e\x9b\x4d\x96\x93\x54\x95\x3e\x45\x06\x12\xd2\xad\xb6\x0b\x18\xa2\xb2\x8a\x13\x87\x02\x5e\x2f\xb1\x51\x68\x47\x0e\x0a\x78\x7c\xba\xb6\x3e\x70\x33\xa2\xfb\x6a\xcb\x13\xa7\x52\xb0\x05\x00\x40\xc2\x8d\x09\x29\xb3\x8e\xa6\x1b\x4a\x27\x69\xf0\x8f\xac\x3c\xa6\xa3\xe0\xff\xae\x9a\x09\x9f\xaf\x5f\xfb\x32\x31\xc0\x21\x91\x54\x47\xdf\x5b\x1c\x33\xd9\x67\x51\x41\x1d\xe2\xd4\xde\xe2\x3b\xeb\x0c\x2d\x0f\xc0\x3a\x7b\xc7\x7f\x6a\x0f\x00\xbf\x90\xe5\x25\xfd\xee\xed\x73\xd0\x10\x7d\x09\xdf\x4b\x47\x93\x6c\x8b\x0b\x80\xd5\x76\x11\xc9\xd7\x9a\x8a\x13\x42\x31\x89\x30\x74\x3d\x54\xf2\xfd\xf6\x12\x70\x75\x6c\x6b\x6e\x33\xfc\x42\xa8\x2a\xf6\x7a\x49\x6f\x34\x48\x61\xce\xba\x6f\x25\xbc\x13\xf6\xda\xda\xfa\x1e\x92\xbc\xc9\xfb\xb0\x7c\x00\x27\x29\x34\x48\x42\x57\xf7\x90\xfc\xf5\xcf\xa7\x24\x0d\x25\xef\xc3\xff\xcb\x2a\x23\x81\x8a\xf5\x9c\xc6\x46\x2d\x3a\x13\xcb\x51\x6a\xe5\x74\x8d\x99\x54\x07\xcd\x12\xd7\x96\x25\x3a\xf4\x99\x3d\x30\x28\x3c\xe9\x92\xfe\xdf\x64\x27\x6e\x3b\xe7\x55\x82\xf0\x0d\xc7\x4b\xd0\xb3\x7f\x76\x8a\x2b\x1d\x8b\xe9\xcf\xb1\x90\x8d\xbf\xf4\x00\xca\x61\x83\x1f\xba\x74\x16\xa9\xb5\x0a\x1e\x38\x89\xcc\xea\x56\x55\xac\x71\x2b\xb8\x83\xa4\x71\xc9\x4d\x05\xc1\xdd\x1f\x78\x76\x71\x11\xbd\x7f\x1e\x6a\xf4\xa9\xf4\xfe\x19\xde\xbe\x05\x3a\x1b\xd4\x87\x70\x2a\x8a\x02\x12\xbd\x7f\xc6\x92\x12\xef\xfa\x33\xfc\xcc\x5e\xf0\xec\x42\x7c\x56\xa3\x3a\x92\x80\x9f\x60\x3d\x95\xf4\x1d\x4b\xf3\x49\x7f\x54\x14\xd7\x93\x26\xa6\x24\x83\x73\xbd\x1d\x0d\xba\x0c\x63\x2a\x4d\xe6\x4c\x2d\x89\x25\x59\xd2\xeb\x76\xd0\x16\x58\x88\x09\x11\x20\x61\x17\xd0\x7d\xf1\x2d\xc8\xbb\xbb\xe9\x12\x42\x2e\x28\xc0\x70\xeb\xf0\xa3\x22\xe6\xa1\x8f\xf2\x69\xbc\x84\xae\x32\xf3\x9f\xdd\x0e\x7e\xf4\x92\xe9\xce\x77\x89\xc5\x90\x8a\xe6\x94\xe2\xb9\x89\x79\xcd\x06\xcc\x03\x95\x3e\x41\x01\x77\x0a\x4f\xf0\x1b\x27\x64\x7d\xe5\x3c\xaf\xb5\x36\x73\x46\x2f\x78\x06\xa9\xe0\x6a\x46\xbd\xa7\x9b\x30\x5f\x28\x38\x1f\x5f\xf0\xfc\x14\xef\x02\x11\xad\x81\x7e\x99\xcd\x30\x7e\x03\xbc\x11\xf1\xdb\x14\xe3\xb2\x9b\xef\xa1\x90\x3f\x33\xad\x13\x4c\xac\xbe\xed\x1e\x92\xfb\x6e\xe6\x6f\x19\xc6\xe9\x6e\xd0\x1e\x91\x89\x74\xe8\x7c\x4c\x95\xe7\xa5\x6e\x4c\x4b\x58\x81\xb1\xda\xa0\x25\x89\x2e\x62\x52\xc9\xc3\xc1\x3f\x6f\x7d\x82\x77\x30\x8a\x26\x32\x87\xa8\x7e\xa1\x55\x4c\xba\x91\xca\x2b\x12\x22\x72\xd8\xac\xd7\x6b\xc8\xe1\xc3\x3a\xe6\x1c\x20\xbb\x02\x36\xeb\x19\xf7\x6c\x59\xd6\xdc\xf9\xe0\xc4\x68\x27\x49\x7e\xc6\x64\x7b\xe3\x56\x50\xc0\x66\x24\x08\x58\x3b\x9c\x25\x7d\xff\xdd\xa4\x7e\xe0\xa5\x3a\x7e\x27\xe7\x0f\x57\x39\xbf\x9d\x43\xe1\x91\xff\x47\x63\xef\xaf\x95\x17\xd9\xd2\x6b\xf4\xd0\x89\xe2\x7b\x8c\x9f\x71\x9e\x3b\x6d\x69\x71\x7b\xd3\xde\xcc\xc6\xd7\xcb\x53\xd8\x4f\xa4\x3c\x59\x3e\xd6\x7c\x53\x14\xb0\x1f\x4e\x31\xf3\x7e\x44\x26\xe4\x6e\xc2\xc1\xcf\xf0\x6e\x03\xf7\x91\x8e\x8b\x59\x50\xbf\x19\x18\xcf\xfc\x42\xd8\x8d\xe7\x7d\x38\xcf\x83\x2f\xe3\xe8\xf4\xaf\x0c\x2a\x79\x44\x47\xdd\x5c\x7a\xef\xbf\x01\x00\x00\xff\xff\x24\xb9\x36\xf7\x82\x08\x00\x00")
+var _jsControllerScannerJs = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\x03\xd5\x55\xdf\x6f\xd3\x30\x10\x7e\xdf\x5f\x71\x8b\x26\x94\x6a\x25\xe9\x40\x9a\xd0\xd6\x82\x10\x3c\x80\xd0\x04\x12\xf0\x34\xed\xc1\x4d\xaf\x8d\x21\xb1\x2d\xdb\x59\x41\x5d\xff\x77\xce\x8e\x93\x38\xed\x86\x78\x25\x0f\x75\x7c\xf7\xdd\xaf\x2f\x77\xd7\x5a\xae\x9a\x0a\xb3\x42\x0a\xab\x65\x55\xa1\x4e\x93\xaf\x05\x13\x02\xf5\xbb\x5e\x94\x4c\x61\xdd\x88\xc2\x72\x29\xd2\x33\x53\x48\x85\x53\x38\x2b\xad\x55\x74\x58\x5e\xa3\x6c\xec\x04\x76\x27\x00\xf7\x4c\x83\x69\xad\x61\x01\x01\x9b\x0d\x92\x2d\x17\x2b\xb9\x8d\x04\xb6\xe4\x66\x72\x4d\x96\x41\x94\xad\x98\x65\x24\xdf\xed\x63\x61\x29\x8d\x35\x24\xbd\xbd\x3b\x94\xde\x30\xd5\xa3\x43\xb4\xb3\x2d\xb3\x45\x99\x92\x00\x20\x61\x4a\x79\x97\x59\xed\xcb\x34\x5d\xe8\x64\xea\xf5\x7d\x55\x0e\xd3\x96\xe0\x9e\x83\x64\xfc\xf1\xf0\x10\xc2\xc4\x00\x83\xd6\x72\xb1\x71\xb9\xc5\x36\x83\x7c\x64\xe5\xd9\xb1\xcc\x36\xc7\xf8\x56\x3a\x42\xf3\x35\xa4\xad\xbc\xad\x7f\x48\x0f\x00\x7f\x59\xcd\x0a\xfb\xc1\xc9\xc7\xa0\xce\x7a\xef\xcf\x7d\x5b\xa6\xd5\x0d\xd2\x0b\x29\x23\xfa\x1a\x45\xa1\x91\x32\xe9\x49\xe8\xb2\xee\x22\xb9\x7c\x03\x05\x4c\x6c\x9a\x8a\xe9\x8c\x02\xa3\x58\xa5\xbb\xfd\xf4\x88\x03\x92\x8c\xaa\x0e\xa9\xf8\x3e\x49\x77\x8d\xae\xae\x20\xc9\xeb\x3c\x98\xe5\x1d\x98\x7a\xab\x46\x5b\xca\x15\xa9\xbf\x7c\xff\x46\x57\x17\xf2\xca\xff\xee\x27\x99\x2d\x51\xa4\xa1\xa6\x3e\x51\x8d\x46\xc5\x74\x50\xf3\x1a\x49\x4d\xcc\xc5\x5a\xa6\x89\x69\x8a\x02\x0d\x3a\xcf\x0e\xe8\x19\x1e\x78\x99\xfe\xab\xb3\x2d\xd3\xad\xf2\xc0\x81\x3f\xfd\x75\x3f\xe2\x13\x05\x5b\x56\x23\x3e\x5b\xc9\xaa\x73\xfe\x14\x13\x01\xf6\x14\x11\x41\xfd\x5f\x70\x11\x46\x50\x30\x21\x63\x22\xdc\x3d\x6e\xaa\xda\x0d\x80\x07\xe5\x70\x81\x97\xad\x3b\x8d\xb6\xd1\x02\x6e\x98\x2d\x33\x2d\x1b\xea\xb2\x9a\x3a\xf1\x1c\x92\xda\x24\x47\x11\x4a\x66\x3e\xe1\x6f\x13\x07\x91\xcb\x1f\x5d\x8c\xe0\x8a\x24\xf0\xec\x19\xd8\xdf\x0a\xe5\xda\xdf\x16\x8b\x05\x24\xf4\x82\x85\x4d\x9c\xea\xb3\x7f\xcd\x7e\x92\x2b\x6f\x9f\x55\x28\x36\xb6\x84\xd7\x30\x1b\x42\xba\x8c\xb9\xfa\x26\x3f\x0a\x1b\xc7\xe3\x2a\x2e\x89\x7b\xe5\xec\xba\x17\xc8\xc2\xaf\x2c\xae\x32\xa3\x2a\x6e\xd3\x24\x4b\x02\x6f\x6b\xa9\x21\xf5\x36\xde\x82\x8e\xb9\x47\x87\xe0\x24\x38\x3f\x1f\x3e\x82\xf7\x45\x40\xc5\xb4\x41\x4a\x21\x75\xd0\x5b\x7e\xd7\x7f\x84\x36\x72\xea\x8e\xf9\x1c\x5e\x39\xca\x64\xab\xdb\xc7\x64\x90\x7e\x5c\x52\xbc\x43\xe2\xba\x46\xcb\xc6\x01\x85\xdc\x92\xfe\x5c\xe0\x16\xde\xd3\xc6\x48\x43\xe4\x3c\xaf\xa4\x54\xe3\x8a\x88\x48\x0a\x04\x07\xfb\xca\x69\xda\x6d\xe3\x02\x79\xe5\x2d\x21\xef\xe2\xbd\x58\x46\x2b\x31\x2c\xf6\x11\xc6\x6d\xc3\xd3\x32\xee\xcd\xb2\x5f\xfc\xed\x33\xfa\xb3\xc8\x54\x63\xca\xb4\x9c\x3c\xae\xee\x9c\xbb\x6c\xc6\xbd\x0c\xfd\xa6\xab\x51\x6f\x30\x2d\xa7\x5d\xe6\xbd\xab\x3c\x2f\x64\xad\x1a\x8b\x2b\x50\x9a\x7a\x51\x5b\x8e\x26\xaa\x64\xc5\xd7\x6b\xd7\xde\xc4\xda\x73\xe8\x49\x2b\x69\x4f\xa2\x78\x6b\x27\x71\xd1\x35\x17\x8e\x11\x6f\x41\x93\x30\x9b\xcd\xe8\xb8\x9c\xc5\x35\x7b\xc8\x7c\x41\xca\x51\xed\xd9\x59\x51\x31\xe3\x8c\x13\x25\x0d\xb7\xfc\x1e\x93\xeb\x23\xb5\x20\xfd\x45\x5f\x20\x60\x65\x70\xe4\xf4\xe5\x93\x4e\xdd\xc0\xd3\x76\x7e\xc2\xe7\x8b\x03\x9f\x8f\xfb\x10\xb8\x61\x7f\x49\xec\xe5\x21\xf3\xa4\x74\x1c\xdd\xb4\xa4\xb8\x1c\xe3\x36\xce\x73\x23\xb5\x3d\x39\xfe\xd2\x4e\x9c\xf6\xdd\xcb\xa6\xb0\x1c\x8a\x72\xc5\xb2\x3e\xe6\x29\x4d\xff\xb2\xbb\xc5\x95\x87\x11\x19\x90\xf3\x01\x07\x6f\xe0\xf9\x05\x5c\x45\x3c\x9e\x8c\x8c\xc2\x66\xa0\x30\x6e\x21\xcc\xfb\xfb\xd2\xdf\xc7\xc6\xfb\x7e\x74\x42\x97\xd1\xa7\xdf\xa0\xb1\xed\x5c\x3a\xed\x1f\x28\xdf\x1e\x43\x8e\x09\x00\x00")
 
 func jsControllerScannerJsBytes() ([]byte, error) {
 	return bindataRead(
@@ -531,8 +552,8 @@ func jsControllerScannerJs() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "js/controller/scanner.js", size: 2178, mode: os.FileMode(420), modTime: time.Unix(1507335680, 0)}
-	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0xe9, 0x16, 0x81, 0xf2, 0xbc, 0x2e, 0x5e, 0x18, 0xe7, 0xb, 0x5f, 0xee, 0x22, 0x63, 0xae, 0x7, 0x48, 0xb4, 0x36, 0xda, 0x98, 0x46, 0x9d, 0xb4, 0x0, 0x61, 0x96, 0x99, 0x3e, 0x1d, 0x67, 0xd6}}
+	info := bindataFileInfo{name: "js/controller/scanner.js", size: 2446, mode: os.FileMode(420), modTime: time.Unix(1792249199, 0)}
+	a := &asset{bytes: bytes, info: info, digest: [32]uint8{0x3, 0x7b, 0xb6, 0xd8, 0xa1, 0x4, 0xce, 0x5d, 0xcf, 0x10, 0x46, 0x88, 0x3f, 0x9d, 0xb7, 0x45, 0xc, 0xf1, 0x1c, 0x23, 0x3, 0xf6, 0x24, 0xf0, 0xd7, 0x23, 0x19, 0x64, 0xbb, 0x39, 0x96, 0x32}}
 	return a, nil
 }
 
@@ -787,6 +808,8 @@ var _bindata = map[string]func() (*asset, error){
 
 	"js/controller/gpio.js": jsControllerGpioJs,
 
+	"js/controller/logs.js": jsControllerLogsJs,
+
 	"js/controller/machine.js": jsControllerMachineJs,
 
 	"js/controller/scanner.js": jsControllerScannerJs,
@@ -881,6 +904,7 @@ var _bintree = &bintree{nil, map[string]*bintree{
 			"auth.js":    &bintree{jsControllerAuthJs, map[string]*bintree{}},
 			"cam.js":     &bintree{jsControllerCamJs, map[string]*bintree{}},
 			"gpio.js":    &bintree{jsControllerGpioJs, map[string]*bintree{}},
+			"logs.js":    &bintree{jsControllerLogsJs, map[string]*bintree{}},
 			"machine.js": &bintree{jsControllerMachineJs, map[string]*bintree{}},
 			"scanner.js": &bintree{jsControllerScannerJs, map[string]*bintree{}},
 		}},
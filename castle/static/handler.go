@@ -3,18 +3,18 @@
 package static
 
 import (
-	"log"
 	"net/http"
 	"os"
 
 	"github.com/elazarl/go-bindata-assetfs"
+	"github.com/jpillora/castlebot/castle/logs"
 )
 
-// all static/ files embedded as a Go library
+//all static/ files embedded as a Go library
 func Handler() http.Handler {
 	var h http.Handler
 	if info, err := os.Stat("castle/static/"); err == nil && info.IsDir() {
-		log.Printf("Use local static files")
+		logs.New("static").Info("Use local static files")
 		h = http.FileServer(http.Dir("castle/static/"))
 	} else {
 		h = http.FileServer(&assetfs.AssetFS{Asset: Asset, AssetDir: AssetDir, AssetInfo: AssetInfo})
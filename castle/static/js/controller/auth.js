@@ -22,4 +22,178 @@ module.controller("AuthController", function($scope, $http, $timeout) {
       }
     );
   };
+
+  //additional accounts
+  auth.roles = ["viewer", "operator", "admin"];
+  auth.users = [];
+  auth.user = {role: "viewer"};
+
+  auth.loadUsers = function() {
+    $http({url: "m/auth/users", method: "GET"}).then(
+      function(resp) {
+        auth.users = resp.data;
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  auth.saveUser = function() {
+    var u = auth.user;
+    var data = {role: u.role, pass: u.pass};
+    $http({url: "m/auth/users/" + encodeURIComponent(u.name), method: "PUT", data: data}).then(
+      function(resp) {
+        auth.user = {role: "viewer"};
+        auth.loadUsers();
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  auth.editUser = function(u) {
+    auth.user = {name: u.name, role: u.role};
+  };
+
+  auth.removeUser = function(u) {
+    $http({url: "m/auth/users/" + encodeURIComponent(u.name), method: "DELETE"}).then(
+      function(resp) {
+        auth.loadUsers();
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  //the password of the current user
+  auth.password = {};
+
+  auth.changePassword = function() {
+    $http({url: "m/auth/password", method: "PUT", data: auth.password}).then(
+      function(resp) {
+        auth.password = {};
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  //api tokens, the secret of a new
+  //token is only shown once
+  auth.tokens = [];
+  auth.token = {role: "operator", scopes: "", ttl: ""};
+  auth.issued = null;
+
+  auth.loadTokens = function() {
+    $http({url: "m/auth/tokens", method: "GET"}).then(
+      function(resp) {
+        auth.tokens = resp.data;
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  auth.createToken = function() {
+    var t = auth.token;
+    var data = {
+      name: t.name,
+      role: t.role,
+      scopes: t.scopes.split(/[\s,]+/).filter(Boolean),
+      ttl: t.ttl || "0s"
+    };
+    $http({url: "m/auth/tokens", method: "POST", data: data}).then(
+      function(resp) {
+        auth.issued = resp.data;
+        auth.token = {role: "operator", scopes: "", ttl: ""};
+        auth.loadTokens();
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  auth.revokeToken = function(t) {
+    $http({url: "m/auth/tokens/" + t.id, method: "DELETE"}).then(
+      function(resp) {
+        auth.loadTokens();
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  //end all lockouts
+  auth.unlock = function() {
+    $http({url: "m/auth/lockouts", method: "DELETE"}).then(
+      function(resp) {
+        console.info("unlocked");
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  //two-factor authentication of the current user
+  auth.me = {};
+  auth.totp = {};
+
+  auth.loadMe = function() {
+    $http({url: "m/auth/me", method: "GET"}).then(
+      function(resp) {
+        auth.me = resp.data;
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  auth.enrollTOTP = function() {
+    $http({url: "m/auth/totp", method: "POST"}).then(
+      function(resp) {
+        auth.totp = {enrollment: resp.data};
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  auth.confirmTOTP = function() {
+    $http({url: "m/auth/totp", method: "PUT", data: {code: auth.totp.code}}).then(
+      function(resp) {
+        auth.totp = {recovery: resp.data.recovery};
+        auth.loadMe();
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  auth.disableTOTP = function() {
+    var data = {code: auth.totp.code};
+    $http({url: "m/auth/totp", method: "DELETE", data: data, headers: {"Content-Type": "application/json"}}).then(
+      function(resp) {
+        auth.totp = {};
+        auth.loadMe();
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  auth.loadMe();
+  auth.loadUsers();
+  auth.loadTokens();
 });
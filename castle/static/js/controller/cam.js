@@ -23,6 +23,17 @@ module.controller("CamController", function($scope, $http) {
     );
   };
 
+  cam.enable = function(enabled) {
+    $http({ url: "m/webcam/enabled", method: "PUT", data: enabled }).then(
+      function(resp) {
+        console.info("succeses", resp.data);
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
   cam.move = function(dir) {
     $http({ url: "m/webcam/move/" + dir, method: "PUT" }).then(
       function(resp) {
@@ -38,8 +49,9 @@ module.controller("CamController", function($scope, $http) {
   $scope.$watch(
     "data.modules.webcam",
     function(state) {
+      cam.enabled = (state || {}).enabled;
       cam.settings = angular.copy((state || {}).settings || {});
-      if (cam.settings.enabled) {
+      if (cam.enabled) {
         cam.started = true;
         refresh();
       } else {
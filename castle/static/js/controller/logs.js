@@ -0,0 +1,65 @@
+module.controller("LogsController", function($scope, $http) {
+  var logs = ($scope.logs = window.logs = this);
+  logs.levels = ["debug", "info", "warn", "error"];
+  logs.module = "server";
+  logs.entries = [];
+  var source = null;
+
+  var append = function(e) {
+    logs.entries.push(e);
+    if (logs.entries.length > 200) {
+      logs.entries.shift();
+    }
+  };
+
+  //load buffered entries, then tail new entries
+  logs.load = function() {
+    logs.close();
+    var url = "m/" + logs.module + "/logs";
+    $http({url: url, method: "GET"}).then(
+      function(resp) {
+        logs.entries = resp.data.slice(-200);
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+    source = new EventSource(url, {withCredentials: true});
+    logs.levels.forEach(function(level) {
+      source.addEventListener(level, function(msg) {
+        $scope.$apply(function() {
+          append(JSON.parse(msg.data));
+        });
+      });
+    });
+  };
+
+  logs.close = function() {
+    if (source) {
+      source.close();
+      source = null;
+    }
+  };
+
+  logs.setLevel = function(level) {
+    $http({url: "m/" + logs.module + "/logs/level", method: "PUT", data: JSON.stringify(level)}).then(
+      function(resp) {
+        console.info("set level", resp.data);
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
+  //only tail while shown
+  $scope.$watch("ui.shown.logs", function(shown) {
+    if (shown) {
+      logs.load();
+    } else {
+      logs.close();
+    }
+  });
+
+  $scope.$on("$destroy", logs.close);
+});
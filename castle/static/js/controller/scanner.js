@@ -28,6 +28,17 @@ module.controller("ScannerController", function($scope, $http, $timeout) {
     );
   };
 
+  scanner.enable = function(enabled) {
+    $http({url: "/m/scanner/enabled", method: "PUT", data: enabled}).then(
+      function(resp) {
+        console.info("succeses", resp.data);
+      },
+      function(resp) {
+        console.warn(resp.data);
+      }
+    );
+  };
+
   $scope.nano = function(nano) {
     var ms = nano / 1e6;
     return Math.round(ms) + "ms";
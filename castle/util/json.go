@@ -22,6 +22,7 @@ func (d Duration) MarshalJSON() ([]byte, error) {
 var intRe = regexp.MustCompile(`^\d+$`)
 
 // UnmarshalJSON implements the json.Unmarshaler interface. The duration is expected to be a quoted-string of a duration in the format accepted by time.ParseDuration.
+// Plain integers are still accepted as seconds, the old format, such as in bundles exported by older versions.
 func (d *Duration) UnmarshalJSON(data []byte) error {
 	//support old int seconds data
 	if intRe.Match(data) {
@@ -40,3 +41,23 @@ func (d *Duration) UnmarshalJSON(data []byte) error {
 	*d = Duration(tmp)
 	return nil
 }
+
+// MigrateSeconds is a settings migration helper which converts the given
+// members from integer seconds (the old Duration format) into Duration strings.
+func MigrateSeconds(settings map[string]interface{}, keys ...string) {
+	for _, k := range keys {
+		if n, ok := settings[k].(float64); ok {
+			settings[k] = time.Duration(n * float64(time.Second)).String()
+		}
+	}
+}
+
+// MigrateNanoseconds is a settings migration helper which converts the given
+// members from integer nanoseconds (marshalled time.Durations) into Duration strings.
+func MigrateNanoseconds(settings map[string]interface{}, keys ...string) {
+	for _, k := range keys {
+		if n, ok := settings[k].(float64); ok {
+			settings[k] = time.Duration(n).String()
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+//MergePatch applies an RFC 7396 JSON merge patch to the target document.
+func MergePatch(target, patch []byte) ([]byte, error) {
+	var t, p interface{}
+	if len(target) > 0 {
+		if err := json.Unmarshal(target, &t); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergePatch(t, p))
+}
+
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+		} else {
+			t[k] = mergePatch(t[k], v)
+		}
+	}
+	return t
+}
+
+//MergeDiff creates an RFC 7396 JSON merge patch which transforms from into to.
+func MergeDiff(from, to []byte) ([]byte, error) {
+	var f, t interface{}
+	if len(from) > 0 {
+		if err := json.Unmarshal(from, &f); err != nil {
+			return nil, err
+		}
+	}
+	if err := json.Unmarshal(to, &t); err != nil {
+		return nil, err
+	}
+	return json.Marshal(mergeDiff(f, t))
+}
+
+func mergeDiff(from, to interface{}) interface{} {
+	f, fok := from.(map[string]interface{})
+	t, tok := to.(map[string]interface{})
+	if !fok || !tok {
+		return to
+	}
+	d := map[string]interface{}{}
+	for k := range f {
+		if _, ok := t[k]; !ok {
+			d[k] = nil
+		}
+	}
+	for k, tv := range t {
+		if fv, ok := f[k]; !ok || !reflect.DeepEqual(fv, tv) {
+			d[k] = mergeDiff(fv, tv)
+		}
+	}
+	return d
+}
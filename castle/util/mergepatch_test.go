@@ -0,0 +1,88 @@
+package util
+
+import "testing"
+
+//RFC 7396 appendix A, with keys sorted as marshalled
+var mergePatchTests = []struct {
+	target, patch, result string
+}{
+	{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+	{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+	{`{"a":"b"}`, `{"a":null}`, `{}`},
+	{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+	{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+	{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+	{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+	{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+	{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+	{`{"a":"b"}`, `["c"]`, `["c"]`},
+	{`{"a":"foo"}`, `null`, `null`},
+	{`{"a":"foo"}`, `"bar"`, `"bar"`},
+	{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+	{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+	{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	//missing targets are patched as empty
+	{``, `{"a":1}`, `{"a":1}`},
+}
+
+func TestMergePatch(t *testing.T) {
+	for _, test := range mergePatchTests {
+		result, err := MergePatch([]byte(test.target), []byte(test.patch))
+		if err != nil {
+			t.Errorf("MergePatch(%s, %s): %s", test.target, test.patch, err)
+			continue
+		}
+		if string(result) != test.result {
+			t.Errorf("MergePatch(%s, %s) = %s, want %s", test.target, test.patch, result, test.result)
+		}
+	}
+}
+
+func TestMergePatchInvalid(t *testing.T) {
+	for _, test := range []struct{ target, patch string }{
+		{`{`, `{}`},
+		{`{}`, `{`},
+	} {
+		if _, err := MergePatch([]byte(test.target), []byte(test.patch)); err == nil {
+			t.Errorf("MergePatch(%s, %s) expected an error", test.target, test.patch)
+		}
+	}
+}
+
+var mergeDiffTests = []struct {
+	from, to, diff string
+}{
+	{`{"a":1}`, `{"a":1}`, `{}`},
+	{`{"a":1}`, `{"a":2}`, `{"a":2}`},
+	{`{"a":1}`, `{}`, `{"a":null}`},
+	{`{}`, `{"a":[1,2]}`, `{"a":[1,2]}`},
+	{`{"a":{"b":1,"c":2}}`, `{"a":{"b":1,"c":3}}`, `{"a":{"c":3}}`},
+	{`{"a":{"b":1}}`, `{"a":"b"}`, `{"a":"b"}`},
+	{`{"a":[1,2]}`, `{"a":[1]}`, `{"a":[1]}`},
+	{`["a"]`, `{"a":1}`, `{"a":1}`},
+	//missing sources diff from nothing
+	{``, `{"a":1}`, `{"a":1}`},
+}
+
+func TestMergeDiff(t *testing.T) {
+	for _, test := range mergeDiffTests {
+		diff, err := MergeDiff([]byte(test.from), []byte(test.to))
+		if err != nil {
+			t.Errorf("MergeDiff(%s, %s): %s", test.from, test.to, err)
+			continue
+		}
+		if string(diff) != test.diff {
+			t.Errorf("MergeDiff(%s, %s) = %s, want %s", test.from, test.to, diff, test.diff)
+			continue
+		}
+		//applying the diff restores to
+		result, err := MergePatch([]byte(test.from), diff)
+		if err != nil {
+			t.Errorf("MergePatch(%s, %s): %s", test.from, diff, err)
+			continue
+		}
+		if want, _ := MergePatch(nil, []byte(test.to)); string(result) != string(want) {
+			t.Errorf("MergePatch(%s, %s) = %s, want %s", test.from, diff, result, want)
+		}
+	}
+}
@@ -1 +1,50 @@
 package util
+
+import "sync"
+
+//Worker manages a single background goroutine which may be
+//started and stopped any number of times.
+type Worker struct {
+	mut     sync.Mutex
+	done    chan struct{}
+	stopped chan struct{}
+}
+
+//Start runs fn in a new goroutine. fn must return once done is closed.
+//Start is a no-op while fn is already running.
+func (w *Worker) Start(fn func(done <-chan struct{})) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	if w.done != nil {
+		return
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	w.done = done
+	w.stopped = stopped
+	go func() {
+		fn(done)
+		close(stopped)
+	}()
+}
+
+//Stop closes done and waits for fn to return.
+//Stop is a no-op while fn is not running.
+func (w *Worker) Stop() {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	if w.done == nil {
+		return
+	}
+	close(w.done)
+	<-w.stopped
+	w.done = nil
+	w.stopped = nil
+}
+
+//Running reports whether fn has been started and not yet stopped
+func (w *Worker) Running() bool {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	return w.done != nil
+}
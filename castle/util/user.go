@@ -0,0 +1,21 @@
+package util
+
+import (
+	"context"
+	"net/http"
+)
+
+type userKey struct{}
+
+// WithUser returns r carrying the name of the
+// authenticated user who made it.
+func WithUser(r *http.Request, name string) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), userKey{}, name))
+}
+
+// RequestUser returns the name of the authenticated user
+// who made r, or "" when authentication is disabled.
+func RequestUser(r *http.Request) string {
+	name, _ := r.Context().Value(userKey{}).(string)
+	return name
+}
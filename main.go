@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	"os"
 	"strconv"
 	"time"
 
@@ -13,10 +14,11 @@ import (
 
 //initial config
 var config = castle.Config{
-	DB:      "castle.db",
-	Name:    "Castlebot",
-	Port:    3000,
-	Updates: false,
+	DB:        "castle.db",
+	Name:      "Castlebot",
+	Port:      3000,
+	Updates:   false,
+	LogFormat: "text",
 }
 
 //BuildTime will be set by the compiler
@@ -26,6 +28,15 @@ var (
 
 func main() {
 	overseer.SanityCheck()
+	//offline subcommands
+	if len(os.Args) > 1 {
+		if cmd, ok := castle.Commands[os.Args[1]]; ok {
+			if err := cmd(config.DB, os.Args[2:]); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+	}
 	//convert epoch to iso string
 	bt := BuildTime
 	if n, err := strconv.ParseInt(BuildTime, 10, 64); err == nil {